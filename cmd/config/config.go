@@ -46,9 +46,15 @@ type OptionalConfig struct {
 }
 
 type RepoConfig struct {
-	Maven    []common.RepoConfig `json:"maven"`
-	Npm      []common.RepoConfig `json:"npm"`
-	Composer []common.RepoConfig `json:"composer"`
+	Maven           []common.RepoConfig `json:"maven"`
+	MavenSettings   string              `json:"maven_settings"`
+	MavenProfiles   []string            `json:"maven_profiles"`
+	MavenProperties map[string]string   `json:"maven_properties"`
+	MavenLocal      bool                `json:"maven_local"`
+	Npm             []common.RepoConfig `json:"npm"`
+	Composer        []common.RepoConfig `json:"composer"`
+	Go              []common.RepoConfig `json:"go"`
+	Vcpkg           []common.RepoConfig `json:"vcpkg"`
 }
 
 type SqlOrigin struct {
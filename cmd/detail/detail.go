@@ -16,6 +16,8 @@ import (
 type DepDetailGraph struct {
 	Dep
 	ID                      string            `json:"id,omitempty" xml:"id,omitempty"`
+	VersionRange            bool              `json:"version_range,omitempty" xml:"version_range,omitempty"`
+	Source                  string            `json:"source,omitempty" xml:"source,omitempty"`
 	Develop                 bool              `json:"dev,omitempty" xml:"dev,omitempty"`
 	Direct                  bool              `json:"direct,omitempty" xml:"direct,omitempty"`
 	Paths                   []string          `json:"paths,omitempty" xml:"paths,omitempty"`
@@ -78,6 +80,8 @@ func (d *DepDetailGraph) Update(dep *model.DepGraph) {
 	}
 	d.Direct = dep.Direct
 	d.Develop = dep.Develop
+	d.VersionRange = dep.VersionRange
+	d.Source = dep.Source
 	for _, lic := range dep.Licenses {
 		d.Licenses = append(d.Licenses, &License{ShortName: lic})
 	}
@@ -199,6 +203,20 @@ func vulnLanguageKey(language model.Language) []string {
 		return []string{"ruby"}
 	case model.Lan_Rust:
 		return []string{"rust"}
+	case model.Lan_DotNet:
+		return []string{"nuget", "csharp"}
+	case model.Lan_Swift:
+		return []string{"swift"}
+	case model.Lan_ObjectiveC:
+		return []string{"cocoapods", "objective-c"}
+	case model.Lan_Erlang:
+		return []string{"erlang", "hex"}
+	case model.Lan_Elixir:
+		return []string{"elixir", "hex"}
+	case model.Lan_Dart:
+		return []string{"dart", "pub"}
+	case model.Lan_Cpp:
+		return []string{"c", "cpp", "conan"}
 	default:
 		return []string{}
 	}
@@ -21,7 +21,9 @@ import (
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/common"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/cpp"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/golang"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/javascript"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/php"
@@ -150,8 +152,14 @@ func args() {
 	}
 
 	java.RegisterMavenRepo(config.Conf().Repo.Maven...)
+	java.RegisterMavenProfiles(config.Conf().Repo.MavenProfiles...)
+	java.RegisterMavenProperties(config.Conf().Repo.MavenProperties)
+	java.RegisterMavenSettings(config.Conf().Repo.MavenSettings)
+	java.RegisterMavenLocalRepo(config.Conf().Repo.MavenLocal)
 	javascript.RegisterNpmRepo(config.Conf().Repo.Npm...)
 	php.RegisterComposerRepo(config.Conf().Repo.Composer...)
+	golang.RegisterGoProxy(config.Conf().Repo.Go...)
+	cpp.RegisterVcpkgRegistry(config.Conf().Repo.Vcpkg...)
 }
 
 func initHttpClient() {
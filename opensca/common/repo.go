@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
@@ -40,6 +42,24 @@ func DownloadUrlFromRepos(route string, do func(repo RepoConfig, r io.Reader), r
 		repoSet[repo.Url] = true
 
 		url := fmt.Sprintf("%s/%s", strings.TrimRight(repo.Url, "/"), strings.TrimLeft(route, "/"))
+
+		// 本地仓库
+		if dir, ok := strings.CutPrefix(repo.Url, "file://"); ok {
+			// file:///C:/xxx
+			if len(dir) > 2 && dir[0] == '/' && dir[2] == ':' {
+				dir = dir[1:]
+			}
+			f, err := os.Open(filepath.Join(filepath.FromSlash(dir), filepath.FromSlash(route)))
+			if err != nil {
+				logs.Debugf("%s %s", err, url)
+				continue
+			}
+			logs.Debugf("open %s", url)
+			do(repo, f)
+			f.Close()
+			return true
+		}
+
 		req, err := http.NewRequest("GET", url, nil)
 		if err != nil {
 			logs.Warn(err)
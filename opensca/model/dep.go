@@ -15,6 +15,10 @@ type DepGraph struct {
 	Name string
 	// 版本号
 	Version string
+	// 版本号为版本范围而非确定版本 例 >=1.0,<2
+	VersionRange bool
+	// 组件来源 例 registry | git | path 为空时未知
+	Source string
 	// 语言
 	Language Language
 	// 检出路径
@@ -26,6 +30,8 @@ type DepGraph struct {
 	Develop bool
 	// 直接依赖
 	Direct bool
+	// 声明为间接依赖 例 go.mod中标记// indirect的模块 不会被标记为直接依赖
+	Indirect bool
 	// 父节点
 	Parents []*DepGraph
 	pset    map[*DepGraph]bool
@@ -36,6 +42,16 @@ type DepGraph struct {
 	Expand any
 }
 
+// 组件来源
+const (
+	// 包管理器仓库
+	SourceRegistry = "registry"
+	// git仓库
+	SourceGit = "git"
+	// 本地路径
+	SourcePath = "path"
+)
+
 // AppendChild 添加子依赖
 func (dep *DepGraph) AppendChild(child *DepGraph) {
 	if dep == nil || child == nil {
@@ -189,7 +205,7 @@ func (dep *DepGraph) Build(deep bool, lan Language) {
 			n.Language = lan
 		}
 		// 直接依赖
-		if len(n.Parents) == 0 || len(p.Parents) == 0 {
+		if (len(n.Parents) == 0 || len(p.Parents) == 0) && !n.Indirect {
 			n.Direct = true
 		}
 		return true
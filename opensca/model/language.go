@@ -17,16 +17,28 @@ const (
 	Lan_Rust       Language = "Rust"
 	Lan_Erlang     Language = "Erlang"
 	Lan_Python     Language = "Python"
+	Lan_DotNet     Language = "DotNet"
+	Lan_Swift      Language = "Swift"
+	Lan_ObjectiveC Language = "ObjectiveC"
+	Lan_Dart       Language = "Dart"
+	Lan_Elixir     Language = "Elixir"
+	Lan_Cpp        Language = "C/C++"
 )
 
 var purlRmap = map[string]Language{
-	"cargo":    Lan_Rust,
-	"composer": Lan_Php,
-	"gem":      Lan_Ruby,
-	"golang":   Lan_Golang,
-	"maven":    Lan_Java,
-	"npm":      Lan_JavaScript,
-	"pypi":     Lan_Python,
+	"cargo":     Lan_Rust,
+	"cocoapods": Lan_ObjectiveC,
+	"composer":  Lan_Php,
+	"conan":     Lan_Cpp,
+	"gem":       Lan_Ruby,
+	"golang":    Lan_Golang,
+	"hex":       Lan_Erlang,
+	"maven":     Lan_Java,
+	"npm":       Lan_JavaScript,
+	"nuget":     Lan_DotNet,
+	"pub":       Lan_Dart,
+	"pypi":      Lan_Python,
+	"swift":     Lan_Swift,
 }
 
 var purlMap = map[Language]string{}
@@ -35,13 +47,21 @@ func init() {
 	for k, v := range purlRmap {
 		purlMap[v] = k
 	}
+	// Elixir与Erlang共用hex
+	purlMap[Lan_Elixir] = "hex"
 }
 
+// VendorVcpkg vcpkg组件的厂商 purl使用generic类型 例 pkg:generic/vcpkg/zlib@1.3
+const VendorVcpkg = "vcpkg"
+
 func Purl(vendor, name, version string, language Language) string {
 	pkg := ""
 	if g, ok := purlMap[language]; ok {
 		pkg = g
 	}
+	if language == Lan_Cpp && vendor == VendorVcpkg {
+		pkg = "generic"
+	}
 	if vendor == "" {
 		return fmt.Sprintf("pkg:%s/%s@%s", pkg, name, version)
 	}
@@ -64,6 +84,8 @@ func ParsePurl(purl string) (vendor, name, version string, language Language) {
 		} else {
 			if l, ok := purlRmap[pkg[1]]; ok {
 				language = l
+			} else if pkg[1] == "generic" && strings.HasPrefix(purl[i+1:], VendorVcpkg+"/") {
+				language = Lan_Cpp
 			}
 		}
 		purl = purl[i+1:]
@@ -76,7 +98,7 @@ func ParsePurl(purl string) (vendor, name, version string, language Language) {
 		name = purl[:i]
 	}
 
-	if language == Lan_Java {
+	if language == Lan_Java || language == Lan_Swift || language == Lan_Cpp {
 		if i := strings.LastIndex(name, "/"); i != -1 {
 			vendor = name[:i]
 			name = name[i+1:]
@@ -82,6 +82,14 @@ func RunTask(ctx context.Context, arg *TaskArg) (result TaskResult) {
 		arg.Sca = sca.AllSca
 	}
 
+	// 是否需要检测可执行文件
+	parseBinary := false
+	for _, s := range arg.Sca {
+		if _, ok := binarySca(s); ok {
+			parseBinary = true
+		}
+	}
+
 	result.Size, result.Error = walk.Walk(ctx, arg.Name, arg.DataOrigin, func(relpath string) bool {
 
 		if arg.ExtractFileFilter != nil && arg.ExtractFileFilter(relpath) {
@@ -94,10 +102,20 @@ func RunTask(ctx context.Context, arg *TaskArg) (result TaskResult) {
 			}
 		}
 
-		return false
+		return parseBinary && filter.Binary(relpath)
 
 	}, arg.IgnoreFileFilter, func(parent *model.File, files []*model.File) {
 
+		// 可执行文件 按文件名初筛后读取文件头确认 每个文件仅读取一次
+		var binaries []*model.File
+		if parseBinary {
+			for _, f := range files {
+				if filter.Binary(f.Relpath()) && filter.BinaryFile(f.Abspath()) {
+					binaries = append(binaries, f)
+				}
+			}
+		}
+
 		for _, sca := range arg.Sca {
 
 			fs := []*model.File{}
@@ -107,7 +125,8 @@ func RunTask(ctx context.Context, arg *TaskArg) (result TaskResult) {
 				}
 			}
 
-			if len(fs) == 0 {
+			bs, ok := binarySca(sca)
+			if len(fs) == 0 && (!ok || len(binaries) == 0) {
 				continue
 			}
 
@@ -120,7 +139,7 @@ func RunTask(ctx context.Context, arg *TaskArg) (result TaskResult) {
 				}
 			}()
 
-			sca.Sca(ctx, parent, fs, func(file *model.File, root ...*model.DepGraph) {
+			call := func(file *model.File, root ...*model.DepGraph) {
 				for _, dep := range root {
 					if dep == nil {
 						continue
@@ -134,7 +153,19 @@ func RunTask(ctx context.Context, arg *TaskArg) (result TaskResult) {
 						arg.ResCallFunc(file, dep)
 					}
 				}
-			})
+			}
+
+			if len(fs) > 0 {
+				sca.Sca(ctx, parent, fs, call)
+			}
+
+			if ok {
+				for _, f := range binaries {
+					if dep := bs.ParseBinary(f); dep != nil {
+						call(f, dep)
+					}
+				}
+			}
 
 			logs.Debugf("end sca:%s file:%s", scaType, parent)
 		}
@@ -143,3 +174,9 @@ func RunTask(ctx context.Context, arg *TaskArg) (result TaskResult) {
 
 	return result
 }
+
+// binarySca 检测器是否解析可执行文件
+func binarySca(s sca.Sca) (sca.BinarySca, bool) {
+	bs, ok := s.(sca.BinarySca)
+	return bs, ok
+}
@@ -0,0 +1,126 @@
+package bazel
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// MavenInstallJson rules_jvm_external生成的maven_install.json文件结构 兼容v1/v2格式
+type MavenInstallJson struct {
+	// v2 key:group:artifact
+	Artifacts map[string]struct {
+		Version string `json:"version"`
+	} `json:"artifacts"`
+	// v2 key:group:artifact[:packaging:classifier] value:依赖的组件
+	Dependencies map[string][]string `json:"dependencies"`
+	// v1
+	DependencyTree struct {
+		Dependencies []struct {
+			// 例 group:artifact:version | group:artifact:packaging:classifier:version
+			Coord              string   `json:"coord"`
+			DirectDependencies []string `json:"directDependencies"`
+		} `json:"dependencies"`
+	} `json:"dependency_tree"`
+}
+
+// ga 组件坐标中的group:artifact
+func ga(coord string) string {
+	parts := strings.SplitN(coord, ":", 3)
+	if len(parts) < 2 {
+		return coord
+	}
+	return parts[0] + ":" + parts[1]
+}
+
+// ParseMavenInstallJson 解析maven_install.json
+// install: MODULE.bazel中对应的maven.install声明 不存在时为nil 为nil时未被依赖的组件为直接依赖
+func ParseMavenInstallJson(file *model.File, install *MavenInstall) *model.DepGraph {
+
+	lock := MavenInstallJson{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	// key:group:artifact
+	nodes := map[string]*model.DepGraph{}
+	var keys []string
+	// key:group:artifact value:依赖的group:artifact
+	edges := map[string][]string{}
+
+	node := func(coord, version string) {
+		key := ga(coord)
+		if _, ok := nodes[key]; ok {
+			return
+		}
+		dep := parseCoordinate(coord)
+		// 跳过无法解析的坐标
+		if dep == nil {
+			return
+		}
+		nodes[key] = &model.DepGraph{Vendor: dep.GroupId, Name: dep.ArtifactId, Version: version}
+		keys = append(keys, key)
+	}
+
+	if len(lock.Artifacts) > 0 {
+		for coord, a := range lock.Artifacts {
+			node(coord, a.Version)
+		}
+		for coord, subs := range lock.Dependencies {
+			for _, sub := range subs {
+				edges[ga(coord)] = append(edges[ga(coord)], ga(sub))
+			}
+		}
+	} else {
+		for _, d := range lock.DependencyTree.Dependencies {
+			dep := parseCoordinate(d.Coord)
+			if dep == nil {
+				continue
+			}
+			node(d.Coord, dep.Version)
+			for _, sub := range d.DirectDependencies {
+				edges[ga(d.Coord)] = append(edges[ga(d.Coord)], ga(sub))
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		subs := edges[key]
+		sort.Strings(subs)
+		for _, sub := range subs {
+			if n, ok := nodes[sub]; ok && n != nodes[key] {
+				nodes[key].AppendChild(n)
+			}
+		}
+	}
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	if install == nil {
+		for _, key := range keys {
+			if len(nodes[key].Parents) == 0 {
+				root.AppendChild(nodes[key])
+			}
+		}
+		return root
+	}
+
+	// maven.install中声明的为直接依赖
+	for _, a := range install.Artifacts {
+		n, ok := nodes[a.GroupId+":"+a.ArtifactId]
+		if !ok {
+			n = &model.DepGraph{Vendor: a.GroupId, Name: a.ArtifactId, Version: a.Version}
+		}
+		n.Develop = a.Scope == "test"
+		root.AppendChild(n)
+	}
+
+	return root
+}
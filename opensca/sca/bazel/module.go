@@ -0,0 +1,220 @@
+package bazel
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+// MavenInstall MODULE.bazel中rules_jvm_external的maven.install声明
+type MavenInstall struct {
+	// 仓库名 默认maven
+	Name         string
+	Artifacts    []*java.PomDependency
+	Repositories []string
+	// 锁文件标签 例 //:maven_install.json
+	LockFile string
+}
+
+// BazelModule MODULE.bazel中声明的模块信息
+type BazelModule struct {
+	Name     string
+	Version  string
+	Installs []*MavenInstall
+}
+
+var (
+	// maven = use_extension("@rules_jvm_external//:extensions.bzl", "maven")
+	useExtensionReg = regexp.MustCompile(`(\w+)\s*=\s*use_extension\(\s*"@rules_jvm_external//:extensions\.bzl"`)
+	// name = "value"
+	stringArgReg = regexp.MustCompile(`(\w+)\s*=\s*"([^"]*)"`)
+	// artifacts = ["g:a:v", ...]
+	listArgReg = regexp.MustCompile(`(\w+)\s*=\s*\[([^\]]*)\]`)
+	stringReg  = regexp.MustCompile(`"([^"]*)"`)
+	// testonly = True
+	boolArgReg = regexp.MustCompile(`(\w+)\s*=\s*(True|False)`)
+)
+
+// calls 查找函数调用的参数文本 例 maven.install(...)
+func calls(text, fn string) []string {
+	var args []string
+	re := regexp.MustCompile(`(?:^|[^\w.])` + regexp.QuoteMeta(fn) + `\s*\(`)
+	for _, idx := range re.FindAllStringIndex(text, -1) {
+		start := idx[1]
+		depth := 1
+		quote := false
+		for i := start; i < len(text); i++ {
+			c := text[i]
+			if quote {
+				if c == '\\' {
+					i++
+				} else if c == '"' {
+					quote = false
+				}
+				continue
+			}
+			switch c {
+			case '"':
+				quote = true
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				args = append(args, text[start:i])
+				break
+			}
+		}
+	}
+	return args
+}
+
+// stringArgs 参数中的字符串值 key:参数名
+func stringArgs(args string) map[string]string {
+	m := map[string]string{}
+	for _, match := range stringArgReg.FindAllStringSubmatch(args, -1) {
+		if _, ok := m[match[1]]; !ok {
+			m[match[1]] = match[2]
+		}
+	}
+	return m
+}
+
+// listArg 参数中的字符串列表
+func listArg(args, name string) []string {
+	var list []string
+	for _, match := range listArgReg.FindAllStringSubmatch(args, -1) {
+		if match[1] != name {
+			continue
+		}
+		for _, s := range stringReg.FindAllStringSubmatch(match[2], -1) {
+			list = append(list, s[1])
+		}
+	}
+	return list
+}
+
+// boolArg 参数中的布尔值
+func boolArg(args, name string) bool {
+	for _, match := range boolArgReg.FindAllStringSubmatch(args, -1) {
+		if match[1] == name {
+			return match[2] == "True"
+		}
+	}
+	return false
+}
+
+// parseCoordinate 解析maven坐标 例 g:a:v | g:a:packaging:v | g:a:packaging:classifier:v
+func parseCoordinate(coord string) *java.PomDependency {
+	parts := strings.Split(coord, ":")
+	if len(parts) < 2 {
+		return nil
+	}
+	dep := &java.PomDependency{GroupId: parts[0], ArtifactId: parts[1]}
+	if len(parts) >= 3 {
+		dep.Version = parts[len(parts)-1]
+	}
+	if len(parts) == 5 {
+		dep.Classifier = parts[3]
+	}
+	return dep
+}
+
+// readStarlark 读取文件内容 忽略注释
+func readStarlark(file *model.File) string {
+	var sb strings.Builder
+	file.ReadLineNoComment(&model.CommentType{Simple: "#"}, func(line string) {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	})
+	return sb.String()
+}
+
+// ReadBazelModule 读取MODULE.bazel
+func ReadBazelModule(file *model.File) *BazelModule {
+
+	text := readStarlark(file)
+	module := &BazelModule{}
+
+	for _, args := range calls(text, "module") {
+		m := stringArgs(args)
+		module.Name = m["name"]
+		module.Version = m["version"]
+	}
+
+	// use_extension的变量名
+	var exts []string
+	for _, match := range useExtensionReg.FindAllStringSubmatch(text, -1) {
+		exts = append(exts, match[1])
+	}
+
+	// key:仓库名
+	installs := map[string]*MavenInstall{}
+	install := func(name string) *MavenInstall {
+		if name == "" {
+			name = "maven"
+		}
+		if i, ok := installs[name]; ok {
+			return i
+		}
+		i := &MavenInstall{Name: name}
+		installs[name] = i
+		module.Installs = append(module.Installs, i)
+		return i
+	}
+
+	for _, ext := range exts {
+
+		for _, args := range calls(text, ext+".install") {
+			m := stringArgs(args)
+			i := install(m["name"])
+			for _, coord := range listArg(args, "artifacts") {
+				if dep := parseCoordinate(coord); dep != nil {
+					i.Artifacts = append(i.Artifacts, dep)
+				}
+			}
+			i.Repositories = append(i.Repositories, listArg(args, "repositories")...)
+			if m["lock_file"] != "" {
+				i.LockFile = m["lock_file"]
+			}
+		}
+
+		// maven.artifact(group = "g", artifact = "a", version = "v", testonly = True)
+		for _, args := range calls(text, ext+".artifact") {
+			m := stringArgs(args)
+			dep := &java.PomDependency{GroupId: m["group"], ArtifactId: m["artifact"], Version: m["version"], Classifier: m["classifier"]}
+			if boolArg(args, "testonly") {
+				dep.Scope = "test"
+			}
+			for _, coord := range listArg(args, "exclusions") {
+				if ex := parseCoordinate(coord); ex != nil {
+					dep.Exclusions = append(dep.Exclusions, ex)
+				}
+			}
+			i := install(m["name"])
+			i.Artifacts = append(i.Artifacts, dep)
+		}
+	}
+
+	return module
+}
+
+// lockPath 锁文件标签对应的路径 例 //third_party:maven_install.json=>third_party/maven_install.json
+func lockPath(dir, label string) string {
+	if label == "" {
+		return filepath.Join(dir, "maven_install.json")
+	}
+	if i := strings.Index(label, "//"); i != -1 {
+		label = label[i+2:]
+	}
+	pkg, name, ok := strings.Cut(label, ":")
+	if !ok {
+		name = path.Base(pkg)
+	}
+	return filepath.Join(dir, filepath.FromSlash(pkg), name)
+}
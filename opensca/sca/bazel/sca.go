@@ -0,0 +1,77 @@
+package bazel
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_Java
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.BazelModule(relpath) || filter.BazelMavenInstall(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// key:relpath
+	locks := map[string]*model.File{}
+	for _, f := range files {
+		if filter.BazelMavenInstall(f.Relpath()) {
+			locks[f.Relpath()] = f
+		}
+	}
+
+	for _, f := range files {
+
+		if !filter.BazelModule(f.Relpath()) {
+			continue
+		}
+
+		module := ReadBazelModule(f)
+		root := &model.DepGraph{Name: module.Name, Version: module.Version, Path: f.Relpath()}
+
+		// 存在锁文件时使用锁文件 否则借助pom解析间接依赖
+		var unlocked []*MavenInstall
+		for _, install := range module.Installs {
+			path := lockPath(filepath.Dir(f.Relpath()), install.LockFile)
+			lock, ok := locks[path]
+			if !ok {
+				unlocked = append(unlocked, install)
+				continue
+			}
+			delete(locks, path)
+			for _, dep := range ParseMavenInstallJson(lock, install).Children {
+				root.AppendChild(dep)
+			}
+		}
+
+		if len(unlocked) > 0 {
+			var deps []*java.PomDependency
+			var repos []string
+			for _, install := range unlocked {
+				deps = append(deps, install.Artifacts...)
+				repos = append(repos, install.Repositories...)
+			}
+			for _, dep := range java.ParseDependencies(ctx, f.Relpath(), deps, repos...).Children {
+				root.AppendChild(dep)
+			}
+		}
+
+		call(f, root)
+	}
+
+	// 未被MODULE.bazel引用的锁文件
+	for _, f := range files {
+		if _, ok := locks[f.Relpath()]; ok {
+			call(f, ParseMavenInstallJson(f, nil))
+		}
+	}
+}
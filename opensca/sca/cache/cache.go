@@ -53,6 +53,10 @@ func Path(vendor, name, version string, language model.Language) string {
 		path = filepath.Join(cacheDir, "npm", fmt.Sprintf("%s.json", name))
 	case model.Lan_Php:
 		path = filepath.Join(cacheDir, "composer", fmt.Sprintf("%s.json", name))
+	case model.Lan_Golang:
+		path = filepath.Join(cacheDir, "gomod", filepath.FromSlash(name), fmt.Sprintf("%s.mod", version))
+	case model.Lan_Cpp:
+		path = filepath.Join(cacheDir, vendor, name, fmt.Sprintf("%s.json", version))
 	default:
 		path = filepath.Join(cacheDir, "none", fmt.Sprintf("%s-%s-%s", vendor, name, version))
 	}
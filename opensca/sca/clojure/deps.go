@@ -0,0 +1,60 @@
+package clojure
+
+import (
+	"context"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+// ParseDepsEdn 解析deps.edn maven依赖借助pom解析间接依赖
+// git依赖使用tag或sha作为版本号 本地依赖版本号留空
+func ParseDepsEdn(ctx context.Context, file *model.File) *model.DepGraph {
+
+	var deps []*java.PomDependency
+	var others []*model.DepGraph
+	var repos []string
+
+	// 例 {org.clojure/clojure {:mvn/version "1.11.1"}}
+	addDeps := func(v any, scope string) {
+		m, _ := v.(Map)
+		for _, lib := range sortedKeys(m) {
+			coord, _ := m[lib].(Map)
+			g, a := splitLib(lib)
+			if version := Str(coord[":mvn/version"]); version != "" {
+				deps = append(deps, &java.PomDependency{GroupId: g, ArtifactId: a, Version: version, Scope: scope, Exclusions: exclusions(coord[":exclusions"])})
+				continue
+			}
+			dep := &model.DepGraph{Vendor: g, Name: a, Develop: scope == "test"}
+			for _, k := range []string{":git/tag", ":tag", ":git/sha", ":sha"} {
+				if v := Str(coord[k]); v != "" {
+					dep.Version = v
+					break
+				}
+			}
+			others = append(others, dep)
+		}
+	}
+
+	forms := readEdn(file)
+	if len(forms) > 0 {
+		if edn, ok := forms[0].(Map); ok {
+			addDeps(edn[":deps"], "")
+			// alias中引入的依赖为开发依赖
+			aliases, _ := edn[":aliases"].(Map)
+			for _, name := range sortedKeys(aliases) {
+				alias, _ := aliases[name].(Map)
+				for _, k := range []string{":extra-deps", ":replace-deps", ":deps"} {
+					addDeps(alias[k], "test")
+				}
+			}
+			repos = repositories(edn[":mvn/repos"])
+		}
+	}
+
+	root := java.ParseDependencies(ctx, file.Relpath(), deps, append(repos, clojars)...)
+	for _, dep := range others {
+		root.AppendChild(dep)
+	}
+	return root
+}
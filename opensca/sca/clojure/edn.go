@@ -0,0 +1,256 @@
+package clojure
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Keyword 关键字 例 :dependencies 不含前缀:
+type Keyword string
+
+// Symbol 符号 例 org.clojure/clojure
+type Symbol string
+
+// List 列表 例 (defproject ...)
+type List []any
+
+// Map 映射 key为关键字/符号/字符串的字面值 关键字保留前缀: 例 :deps | org.clojure/clojure
+type Map map[string]any
+
+// ednParser Clojure/EDN字面量解析 用于project.clj及deps.edn
+type ednParser struct {
+	s []rune
+	i int
+}
+
+// ParseEdn 解析全部顶层字面量
+// 列表=>List 向量/集合=>[]any 映射=>Map 字符串/正则/字符=>string 关键字=>Keyword 符号/数字=>Symbol
+func ParseEdn(reader io.Reader) ([]any, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	p := &ednParser{s: []rune(string(data))}
+	var forms []any
+	for {
+		p.skip()
+		if p.eof() {
+			return forms, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return forms, err
+		}
+		forms = append(forms, v)
+	}
+}
+
+func (p *ednParser) eof() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *ednParser) peek(n int) rune {
+	if p.i+n < len(p.s) {
+		return p.s[p.i+n]
+	}
+	return 0
+}
+
+func (p *ednParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("edn offset %d: %s", p.i, fmt.Sprintf(format, args...))
+}
+
+// skip 跳过空白、逗号、注释及#_忽略的字面量
+func (p *ednParser) skip() {
+	for !p.eof() {
+		c := p.peek(0)
+		switch {
+		case unicode.IsSpace(c) || c == ',':
+			p.i++
+		case c == ';':
+			for !p.eof() && p.peek(0) != '\n' {
+				p.i++
+			}
+		case c == '#' && p.peek(1) == '_':
+			p.i += 2
+			p.value()
+		default:
+			return
+		}
+	}
+}
+
+func isDelimiter(c rune) bool {
+	return unicode.IsSpace(c) || strings.ContainsRune(`,()[]{}";`, c)
+}
+
+// token 读取符号/关键字/数字
+func (p *ednParser) token() string {
+	start := p.i
+	for !p.eof() && !isDelimiter(p.peek(0)) {
+		p.i++
+	}
+	return string(p.s[start:p.i])
+}
+
+// str 读取字符串
+func (p *ednParser) str() (string, error) {
+	p.i++
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.peek(0)
+		p.i++
+		switch c {
+		case '\\':
+			if !p.eof() {
+				sb.WriteRune(p.peek(0))
+				p.i++
+			}
+		case '"':
+			return sb.String(), nil
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// seq 读取元素直到close
+func (p *ednParser) seq(close rune) ([]any, error) {
+	items := []any{}
+	for {
+		p.skip()
+		if p.eof() {
+			return nil, p.errorf("missing %c", close)
+		}
+		if p.peek(0) == close {
+			p.i++
+			return items, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+}
+
+// mapping 读取映射
+func (p *ednParser) mapping() (Map, error) {
+	items, err := p.seq('}')
+	if err != nil {
+		return nil, err
+	}
+	m := Map{}
+	for i := 0; i+1 < len(items); i += 2 {
+		m[Key(items[i])] = items[i+1]
+	}
+	return m, nil
+}
+
+// value 读取单个字面量
+func (p *ednParser) value() (any, error) {
+	p.skip()
+	if p.eof() {
+		return nil, p.errorf("unexpected end")
+	}
+	switch c := p.peek(0); c {
+	case '(':
+		p.i++
+		items, err := p.seq(')')
+		return List(items), err
+	case '[':
+		p.i++
+		return p.seq(']')
+	case '{':
+		p.i++
+		return p.mapping()
+	case '"':
+		return p.str()
+	case '\\':
+		// 字符 例 \a | \newline
+		p.i++
+		start := p.i
+		p.i++
+		for !p.eof() && !isDelimiter(p.peek(0)) {
+			p.i++
+		}
+		return string(p.s[start:p.i]), nil
+	case '\'', '`', '~', '@':
+		// 引用 例 'foo | ~@foo
+		p.i++
+		if c == '~' && p.peek(0) == '@' {
+			p.i++
+		}
+		return p.value()
+	case '^':
+		// 元数据 例 ^:private ^{:doc ""} 忽略元数据
+		p.i++
+		if _, err := p.value(); err != nil {
+			return nil, err
+		}
+		return p.value()
+	case '#':
+		switch p.peek(1) {
+		case '{':
+			// 集合
+			p.i += 2
+			return p.seq('}')
+		case '"':
+			// 正则
+			p.i++
+			return p.str()
+		case '(':
+			// 匿名函数
+			p.i++
+			return p.value()
+		case '?':
+			// 读取条件 例 #?(:clj x :cljs y) #?@(...)
+			p.i += 2
+			if p.peek(0) == '@' {
+				p.i++
+			}
+			return p.value()
+		default:
+			// 标签 例 #inst "..." 保留标签后的字面量
+			p.i++
+			p.token()
+			return p.value()
+		}
+	case ')', ']', '}':
+		return nil, p.errorf("unexpected %q", c)
+	}
+	t := p.token()
+	if strings.HasPrefix(t, ":") {
+		return Keyword(t[1:]), nil
+	}
+	return Symbol(t), nil
+}
+
+// Key 字面量作为映射key时的字面值
+func Key(v any) string {
+	switch k := v.(type) {
+	case Keyword:
+		return ":" + string(k)
+	case Symbol:
+		return string(k)
+	case string:
+		return k
+	}
+	return fmt.Sprint(v)
+}
+
+// Str 字符串/符号/关键字的字面值
+func Str(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case Symbol:
+		return string(s)
+	case Keyword:
+		return string(s)
+	}
+	return ""
+}
@@ -0,0 +1,202 @@
+package clojure
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+// clojars Leiningen及tools.deps默认使用的仓库
+const clojars = "https://repo.clojars.org"
+
+// readEdn 读取文件中的全部字面量
+func readEdn(file *model.File) []any {
+	var forms []any
+	file.OpenReader(func(reader io.Reader) {
+		var err error
+		forms, err = ParseEdn(reader)
+		if err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+	return forms
+}
+
+// splitLib 拆分依赖名 例 org.clojure/clojure=>org.clojure,clojure cheshire=>cheshire,cheshire
+func splitLib(lib string) (groupId, artifactId string) {
+	if i := strings.Index(lib, "/"); i != -1 {
+		return lib[:i], lib[i+1:]
+	}
+	return lib, lib
+}
+
+// exclusions 解析排除列表 例 [commons-codec [org.slf4j/slf4j-api :classifier "x"]]
+func exclusions(v any) []*java.PomDependency {
+	var exs []*java.PomDependency
+	items, _ := v.([]any)
+	for _, item := range items {
+		if vec, ok := item.([]any); ok && len(vec) > 0 {
+			item = vec[0]
+		}
+		if lib := Str(item); lib != "" {
+			g, a := splitLib(lib)
+			exs = append(exs, &java.PomDependency{GroupId: g, ArtifactId: a})
+		}
+	}
+	return exs
+}
+
+// leinDependencies 解析依赖列表 例 [[ring/ring-core "1.9.6" :exclusions [commons-codec] :scope "test"]]
+// managed: :managed-dependencies中的版本 key:依赖名
+func leinDependencies(v any, scope string, managed map[string]string) []*java.PomDependency {
+	var deps []*java.PomDependency
+	items, _ := v.([]any)
+	for _, item := range items {
+		vec, ok := item.([]any)
+		if !ok || len(vec) == 0 {
+			continue
+		}
+		lib := Str(vec[0])
+		g, a := splitLib(lib)
+		dep := &java.PomDependency{GroupId: g, ArtifactId: a, Scope: scope}
+		opts := vec[1:]
+		if len(opts) > 0 {
+			if s, ok := opts[0].(string); ok {
+				dep.Version = s
+				opts = opts[1:]
+			}
+		}
+		if dep.Version == "" {
+			dep.Version = managed[lib]
+		}
+		for i := 0; i+1 < len(opts); i += 2 {
+			switch Key(opts[i]) {
+			case ":exclusions":
+				dep.Exclusions = exclusions(opts[i+1])
+			case ":scope":
+				if dep.Scope == "" {
+					dep.Scope = Str(opts[i+1])
+				}
+			case ":classifier":
+				dep.Classifier = Str(opts[i+1])
+			case ":optional":
+				dep.Optional = Str(opts[i+1]) == "true"
+			}
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// repositories 解析仓库地址 例 [["clojars" "https://xxx"] ["private" {:url "https://xxx"}]] | {"clojars" {:url "https://xxx"}}
+func repositories(v any) []string {
+	var urls []string
+	add := func(repo any) {
+		switch r := repo.(type) {
+		case string:
+			urls = append(urls, r)
+		case Map:
+			if url := Str(r[":url"]); url != "" {
+				urls = append(urls, url)
+			}
+		}
+	}
+	switch rs := v.(type) {
+	case []any:
+		for _, item := range rs {
+			if vec, ok := item.([]any); ok && len(vec) == 2 {
+				add(vec[1])
+			}
+		}
+	case Map:
+		for _, k := range sortedKeys(rs) {
+			add(rs[k])
+		}
+	}
+	return urls
+}
+
+func sortedKeys(m Map) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LeinProject project.clj中声明的项目信息
+type LeinProject struct {
+	GroupId      string
+	ArtifactId   string
+	Version      string
+	Dependencies []*java.PomDependency
+	Repositories []string
+}
+
+// ReadLeinProject 读取project.clj
+func ReadLeinProject(file *model.File) *LeinProject {
+
+	project := &LeinProject{}
+
+	for _, form := range readEdn(file) {
+
+		// (defproject group/name "version" :key value ...)
+		list, ok := form.(List)
+		if !ok || len(list) < 3 || Str(list[0]) != "defproject" {
+			continue
+		}
+
+		project.GroupId, project.ArtifactId = splitLib(Str(list[1]))
+		project.Version = Str(list[2])
+
+		opts := Map{}
+		for i := 3; i+1 < len(list); i += 2 {
+			opts[Key(list[i])] = list[i+1]
+		}
+
+		managed := map[string]string{}
+		for _, dep := range leinDependencies(opts[":managed-dependencies"], "", nil) {
+			managed[dep.GroupId+"/"+dep.ArtifactId] = dep.Version
+			if dep.GroupId == dep.ArtifactId {
+				managed[dep.ArtifactId] = dep.Version
+			}
+		}
+
+		project.Dependencies = leinDependencies(opts[":dependencies"], "", managed)
+
+		// profiles中的依赖 provided之外均为开发依赖
+		if profiles, ok := opts[":profiles"].(Map); ok {
+			for _, name := range sortedKeys(profiles) {
+				profile, ok := profiles[name].(Map)
+				if !ok {
+					continue
+				}
+				scope := "test"
+				if name == ":provided" {
+					scope = "provided"
+				}
+				project.Dependencies = append(project.Dependencies, leinDependencies(profile[":dependencies"], scope, managed)...)
+			}
+		}
+
+		project.Repositories = append(repositories(opts[":repositories"]), clojars)
+	}
+
+	return project
+}
+
+// ParseLeinProject 借助pom解析project.clj的间接依赖
+func ParseLeinProject(ctx context.Context, file *model.File) *model.DepGraph {
+	project := ReadLeinProject(file)
+	root := java.ParseDependencies(ctx, file.Relpath(), project.Dependencies, project.Repositories...)
+	root.Vendor = project.GroupId
+	root.Name = project.ArtifactId
+	root.Version = project.Version
+	return root
+}
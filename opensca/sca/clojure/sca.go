@@ -0,0 +1,29 @@
+package clojure
+
+import (
+	"context"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_Java
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.ClojureLeinProject(relpath) || filter.ClojureDepsEdn(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+	for _, f := range files {
+		if filter.ClojureLeinProject(f.Relpath()) {
+			call(f, ParseLeinProject(ctx, f))
+		}
+		if filter.ClojureDepsEdn(f.Relpath()) {
+			call(f, ParseDepsEdn(ctx, f))
+		}
+	}
+}
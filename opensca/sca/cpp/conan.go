@@ -0,0 +1,202 @@
+package cpp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// ConanLock conan.lock文件结构 兼容conan1.x(0.4)及conan2.x格式
+type ConanLock struct {
+	Version string `json:"version"`
+	// conan1.x key:节点id 0为根节点
+	GraphLock struct {
+		Nodes map[string]struct {
+			// 例 openssl/1.1.1k@user/channel#rrev
+			Ref           string   `json:"ref"`
+			Path          string   `json:"path"`
+			Requires      []string `json:"requires"`
+			BuildRequires []string `json:"build_requires"`
+			// host | build
+			Context string `json:"context"`
+		} `json:"nodes"`
+	} `json:"graph_lock"`
+	// conan2.x 例 zlib/1.2.13#rrev%timestamp
+	Requires       []string `json:"requires"`
+	BuildRequires  []string `json:"build_requires"`
+	PythonRequires []string `json:"python_requires"`
+}
+
+// parseConanRef 解析conan引用 例 openssl/1.1.1k@user/channel#rrev%timestamp
+// 版本范围 例 openssl/[>=1.1 <4] 返回的version为>=1.1 <4
+func parseConanRef(ref string) (name, version string, versionRange bool) {
+	ref = strings.TrimSpace(ref)
+	if i := strings.IndexAny(ref, "#@"); i != -1 {
+		ref = ref[:i]
+	}
+	name, version, _ = strings.Cut(ref, "/")
+	if strings.HasPrefix(version, "[") {
+		version = strings.Trim(version, "[]")
+		// 例 [>=1.1 <4, include_prerelease]
+		if i := strings.Index(version, ","); i != -1 {
+			version = strings.TrimSpace(version[:i])
+		}
+		versionRange = true
+	}
+	return
+}
+
+// ConanFile conanfile.txt中声明的依赖
+type ConanFile struct {
+	// [requires]
+	Requires []string
+	// [tool_requires] [build_requires] [test_requires]
+	ToolRequires []string
+}
+
+// ReadConanFile 读取conanfile.txt
+func ReadConanFile(file *model.File) *ConanFile {
+	conanfile := &ConanFile{}
+	file.OpenReader(func(reader io.Reader) {
+		section := ""
+		scan := bufio.NewScanner(reader)
+		for scan.Scan() {
+			line := strings.TrimSpace(scan.Text())
+			if i := strings.Index(line, "#"); i != -1 {
+				line = strings.TrimSpace(line[:i])
+			}
+			if line == "" {
+				continue
+			}
+			if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") && !strings.Contains(line, "/") {
+				section = strings.Trim(line, "[]")
+				continue
+			}
+			switch section {
+			case "requires":
+				conanfile.Requires = append(conanfile.Requires, line)
+			case "tool_requires", "build_requires", "test_requires":
+				conanfile.ToolRequires = append(conanfile.ToolRequires, line)
+			}
+		}
+	})
+	return conanfile
+}
+
+// ParseConanFile 解析conanfile.txt
+func ParseConanFile(file *model.File) *model.DepGraph {
+	root := &model.DepGraph{Path: file.Relpath()}
+	conanfile := ReadConanFile(file)
+	for _, ref := range conanfile.Requires {
+		name, version, versionRange := parseConanRef(ref)
+		root.AppendChild(&model.DepGraph{Name: name, Version: version, VersionRange: versionRange})
+	}
+	for _, ref := range conanfile.ToolRequires {
+		name, version, versionRange := parseConanRef(ref)
+		root.AppendChild(&model.DepGraph{Name: name, Version: version, VersionRange: versionRange, Develop: true})
+	}
+	return root
+}
+
+// ParseConanLock 解析conan.lock
+// conanfile: 同目录下的conanfile.txt 不存在时为nil
+func ParseConanLock(file *model.File, conanfile *ConanFile) *model.DepGraph {
+
+	lock := ConanLock{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	if len(lock.GraphLock.Nodes) > 0 {
+		return parseConanGraphLock(file, lock)
+	}
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	// conan2.x的conan.lock中没有依赖关系 conanfile.txt中声明的为直接依赖
+	direct := map[string]bool{}
+	if conanfile != nil {
+		for _, ref := range append(conanfile.Requires, conanfile.ToolRequires...) {
+			name, _, _ := parseConanRef(ref)
+			direct[name] = true
+		}
+	}
+
+	for _, ref := range lock.Requires {
+		name, version, _ := parseConanRef(ref)
+		root.AppendChild(&model.DepGraph{Name: name, Version: version, Indirect: conanfile != nil && !direct[name]})
+	}
+	for _, ref := range lock.BuildRequires {
+		name, version, _ := parseConanRef(ref)
+		root.AppendChild(&model.DepGraph{Name: name, Version: version, Develop: true, Indirect: conanfile != nil && !direct[name]})
+	}
+
+	return root
+}
+
+// parseConanGraphLock 解析conan1.x的conan.lock graph_lock中记录了完整的依赖关系
+func parseConanGraphLock(file *model.File, lock ConanLock) *model.DepGraph {
+
+	nodes := lock.GraphLock.Nodes
+
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+
+	deps := map[string]*model.DepGraph{}
+	for _, id := range ids {
+		node := nodes[id]
+		name, version, _ := parseConanRef(node.Ref)
+		deps[id] = &model.DepGraph{Name: name, Version: version, Develop: node.Context == "build"}
+	}
+
+	for _, id := range ids {
+		dep := deps[id]
+		for _, sub := range nodes[id].Requires {
+			if d, ok := deps[sub]; ok {
+				dep.AppendChild(d)
+			}
+		}
+		for _, sub := range nodes[id].BuildRequires {
+			if d, ok := deps[sub]; ok {
+				d.Develop = true
+				dep.AppendChild(d)
+			}
+		}
+	}
+
+	// 根节点id通常为0 不存在时以唯一没有父节点的组件作为根节点 否则没有父节点的组件均作为直接依赖
+	root, ok := deps["0"]
+	if !ok {
+		var tops []*model.DepGraph
+		for _, id := range ids {
+			if len(deps[id].Parents) == 0 {
+				tops = append(tops, deps[id])
+			}
+		}
+		if len(tops) == 1 {
+			root = tops[0]
+		} else {
+			root = &model.DepGraph{}
+			for _, top := range tops {
+				root.AppendChild(top)
+			}
+		}
+	}
+	root.Path = file.Relpath()
+	return root
+}
@@ -0,0 +1,65 @@
+package cpp
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_Cpp
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.CppConanLock(relpath) ||
+		filter.CppConanFile(relpath) ||
+		filter.CppVcpkgJson(relpath) ||
+		filter.CppVcpkgConfiguration(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// map[dir]*File
+	locks := map[string]*model.File{}
+	conanfiles := map[string]*model.File{}
+	configs := map[string]*model.File{}
+	for _, f := range files {
+		dir := filepath.Dir(f.Relpath())
+		if filter.CppConanLock(f.Relpath()) {
+			locks[dir] = f
+		}
+		if filter.CppConanFile(f.Relpath()) {
+			conanfiles[dir] = f
+		}
+		if filter.CppVcpkgConfiguration(f.Relpath()) {
+			configs[dir] = f
+		}
+	}
+
+	// conan.lock > conanfile.txt
+	for _, f := range files {
+		dir := filepath.Dir(f.Relpath())
+		switch {
+		case filter.CppConanLock(f.Relpath()):
+			var conanfile *ConanFile
+			if c, ok := conanfiles[dir]; ok {
+				conanfile = ReadConanFile(c)
+			}
+			call(f, ParseConanLock(f, conanfile))
+		case filter.CppConanFile(f.Relpath()):
+			if _, ok := locks[dir]; !ok {
+				call(f, ParseConanFile(f))
+			}
+		case filter.CppVcpkgJson(f.Relpath()):
+			var config *VcpkgConfiguration
+			if c, ok := configs[dir]; ok {
+				config = ReadVcpkgConfiguration(c)
+			}
+			call(f, ParseVcpkgJson(f, config))
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package cpp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/common"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/cache"
+)
+
+// VcpkgJson vcpkg.json文件结构
+type VcpkgJson struct {
+	Name string `json:"name"`
+	// version | version-semver | version-date | version-string
+	Version       string `json:"version"`
+	VersionSemver string `json:"version-semver"`
+	VersionDate   string `json:"version-date"`
+	VersionString string `json:"version-string"`
+	// 内置仓库的基线 vcpkg仓库的commit
+	BuiltinBaseline string `json:"builtin-baseline"`
+	// 字符串或对象
+	Dependencies []json.RawMessage `json:"dependencies"`
+	Overrides    []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"overrides"`
+	Configuration *VcpkgConfiguration `json:"vcpkg-configuration"`
+}
+
+// VcpkgDependency vcpkg.json中的依赖
+type VcpkgDependency struct {
+	Name string `json:"name"`
+	// 最低版本
+	MinVersion string `json:"version>="`
+	// 宿主依赖 构建时使用的工具
+	Host bool `json:"host"`
+}
+
+// VcpkgConfiguration vcpkg-configuration.json文件结构
+type VcpkgConfiguration struct {
+	DefaultRegistry *struct {
+		// builtin | git | filesystem
+		Kind     string `json:"kind"`
+		Baseline string `json:"baseline"`
+	} `json:"default-registry"`
+}
+
+// readJson 读取json文件
+func readJson(file *model.File, v any) {
+	file.OpenReader(func(reader io.Reader) {
+		if err := json.NewDecoder(reader).Decode(v); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+}
+
+// ReadVcpkgConfiguration 读取vcpkg-configuration.json
+func ReadVcpkgConfiguration(file *model.File) *VcpkgConfiguration {
+	config := &VcpkgConfiguration{}
+	readJson(file, config)
+	return config
+}
+
+// trimPortVersion 去掉版本号中的port-version 例 1.2.13#1
+func trimPortVersion(version string) string {
+	if i := strings.Index(version, "#"); i != -1 {
+		return version[:i]
+	}
+	return version
+}
+
+// compareVcpkgVersion 按数字段比较版本号 返回-1/0/1
+func compareVcpkgVersion(a, b string) int {
+	split := func(s string) []string {
+		return strings.FieldsFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	}
+	as, bs := split(a), split(b)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// ParseVcpkgJson 解析vcpkg.json 依赖版本取 overrides > max(基线版本, version>=)
+// vcpkg.json中没有间接依赖 仅记录直接依赖
+// config: 同目录下的vcpkg-configuration.json 不存在时为nil
+func ParseVcpkgJson(file *model.File, config *VcpkgConfiguration) *model.DepGraph {
+
+	manifest := VcpkgJson{}
+	readJson(file, &manifest)
+
+	root := &model.DepGraph{Name: manifest.Name, Path: file.Relpath()}
+	for _, v := range []string{manifest.Version, manifest.VersionSemver, manifest.VersionDate, manifest.VersionString} {
+		if v != "" {
+			root.Version = v
+			break
+		}
+	}
+
+	// vcpkg-configuration.json优先于vcpkg.json中的配置
+	if config == nil {
+		config = manifest.Configuration
+	}
+	baseline := manifest.BuiltinBaseline
+	if config != nil && config.DefaultRegistry != nil && config.DefaultRegistry.Baseline != "" {
+		baseline = config.DefaultRegistry.Baseline
+	}
+	var versions map[string]string
+	if baseline != "" {
+		versions = vcpkgBaselineOrigin(baseline)
+	}
+
+	overrides := map[string]string{}
+	for _, o := range manifest.Overrides {
+		overrides[o.Name] = trimPortVersion(o.Version)
+	}
+
+	for _, raw := range manifest.Dependencies {
+		dep := VcpkgDependency{}
+		if err := json.Unmarshal(raw, &dep.Name); err != nil {
+			if err := json.Unmarshal(raw, &dep); err != nil {
+				logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+				continue
+			}
+		}
+		if dep.Name == "" {
+			continue
+		}
+		node := &model.DepGraph{Vendor: model.VendorVcpkg, Name: dep.Name, Develop: dep.Host}
+		min := trimPortVersion(dep.MinVersion)
+		if v, ok := overrides[dep.Name]; ok {
+			node.Version = v
+		} else if v, ok := versions[dep.Name]; ok {
+			node.Version = v
+			if min != "" && compareVcpkgVersion(v, min) < 0 {
+				node.Version = min
+			}
+		} else if min != "" {
+			// 无法获取基线时仅能确定最低版本
+			node.Version = ">=" + min
+			node.VersionRange = true
+		}
+		root.AppendChild(node)
+	}
+
+	return root
+}
+
+// vcpkgRegistry vcpkg仓库 用于获取基线 为空时不获取
+var vcpkgRegistry []common.RepoConfig
+
+// RegisterVcpkgRegistry 注册vcpkg仓库
+func RegisterVcpkgRegistry(repos ...common.RepoConfig) {
+	newRepo := common.TrimRepo(repos...)
+	if len(newRepo) > 0 {
+		vcpkgRegistry = newRepo
+	}
+}
+
+// VcpkgBaseline versions/baseline.json文件结构
+type VcpkgBaseline struct {
+	Default map[string]struct {
+		Baseline    string `json:"baseline"`
+		PortVersion int    `json:"port-version"`
+	} `json:"default"`
+}
+
+var (
+	baselineCache = map[string]map[string]string{}
+	baselineMutex = sync.Mutex{}
+)
+
+// vcpkgBaselineOrigin 获取基线中各port的版本 key:port名 无法获取时返回nil
+var vcpkgBaselineOrigin = func(baseline string) map[string]string {
+
+	if len(vcpkgRegistry) == 0 {
+		return nil
+	}
+
+	baselineMutex.Lock()
+	defer baselineMutex.Unlock()
+
+	if versions, ok := baselineCache[baseline]; ok {
+		return versions
+	}
+
+	var versions map[string]string
+	parse := func(reader io.Reader) {
+		b := VcpkgBaseline{}
+		if err := json.NewDecoder(reader).Decode(&b); err != nil {
+			logs.Warn(err)
+			return
+		}
+		versions = map[string]string{}
+		for name, v := range b.Default {
+			versions[name] = v.Baseline
+		}
+	}
+
+	// 读取缓存
+	cachePath := cache.Path(model.VendorVcpkg, "baseline", baseline, model.Lan_Cpp)
+	cache.Load(cachePath, parse)
+
+	// 从仓库下载
+	if versions == nil {
+		route := fmt.Sprintf("%s/versions/baseline.json", baseline)
+		common.DownloadUrlFromRepos(route, func(repo common.RepoConfig, r io.Reader) {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				logs.Warn(err)
+				return
+			}
+			parse(bytes.NewReader(data))
+			if versions != nil {
+				cache.Save(cachePath, bytes.NewReader(data))
+			}
+		}, vcpkgRegistry...)
+	}
+
+	baselineCache[baseline] = versions
+	return versions
+}
+
+// RegisterVcpkgBaselineOrigin 注册vcpkg基线数据源
+func RegisterVcpkgBaselineOrigin(origin func(baseline string) map[string]string) {
+	if origin != nil {
+		vcpkgBaselineOrigin = origin
+	}
+}
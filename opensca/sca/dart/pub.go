@@ -0,0 +1,88 @@
+package dart
+
+import (
+	"io"
+	"sort"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PubspecLock pubspec.lock文件结构
+type PubspecLock struct {
+	Packages map[string]struct {
+		// direct main | direct dev | direct overridden | transitive
+		Dependency string `yaml:"dependency"`
+		// hosted | git | path | sdk
+		Source  string `yaml:"source"`
+		Version string `yaml:"version"`
+	} `yaml:"packages"`
+}
+
+// Pubspec pubspec.yaml文件结构
+type Pubspec struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// readPubspec 读取pubspec.yaml
+func readPubspec(file *model.File) *Pubspec {
+	pubspec := &Pubspec{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := yaml.NewDecoder(reader).Decode(pubspec); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+	return pubspec
+}
+
+// ParsePubspecLock 解析pubspec.lock 文件中没有依赖关系 间接依赖同样挂在根节点下 忽略sdk中的包
+// pubspec: 同目录下的pubspec.yaml 不存在时为nil
+func ParsePubspecLock(file, pubspec *model.File) *model.DepGraph {
+
+	lock := PubspecLock{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := yaml.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	root := &model.DepGraph{Path: file.Relpath()}
+	if pubspec != nil {
+		p := readPubspec(pubspec)
+		root.Name = p.Name
+		root.Version = p.Version
+		root.Path = pubspec.Relpath()
+	}
+
+	names := make([]string, 0, len(lock.Packages))
+	for name := range lock.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pkg := lock.Packages[name]
+		// sdk中的包(flutter等)随sdk发布 不是pub.dev中的组件
+		if pkg.Source == "sdk" {
+			continue
+		}
+		dep := &model.DepGraph{Name: name, Version: pkg.Version}
+		// 本地包 版本号留空
+		if pkg.Source == "path" {
+			dep.Version = ""
+		}
+		switch pkg.Dependency {
+		case "direct dev":
+			dep.Develop = true
+		case "direct main", "direct overridden":
+		default:
+			dep.Indirect = true
+		}
+		root.AppendChild(dep)
+	}
+
+	return root
+}
@@ -0,0 +1,36 @@
+package dart
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_Dart
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.DartPubspecLock(relpath) || filter.DartPubspec(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// map[dir]*File
+	pubspec := map[string]*model.File{}
+	for _, f := range files {
+		if filter.DartPubspec(f.Relpath()) {
+			pubspec[filepath.Dir(f.Relpath())] = f
+		}
+	}
+
+	for _, f := range files {
+		if filter.DartPubspecLock(f.Relpath()) {
+			call(f, ParsePubspecLock(f, pubspec[filepath.Dir(f.Relpath())]))
+		}
+	}
+}
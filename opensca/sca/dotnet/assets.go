@@ -0,0 +1,89 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// ProjectAssets obj/project.assets.json文件结构
+type ProjectAssets struct {
+	Version int `json:"version"`
+	// key:目标框架 例 net6.0 | net6.0/win-x64
+	Targets map[string]map[string]struct {
+		// package | project
+		Type string `json:"type"`
+		// key:依赖的包名 value:版本约束
+		Dependencies map[string]string `json:"dependencies"`
+	} `json:"targets"`
+	Project struct {
+		Restore struct {
+			ProjectName string `json:"projectName"`
+		} `json:"restore"`
+		Frameworks map[string]struct {
+			// 项目直接引用的包
+			Dependencies map[string]any `json:"dependencies"`
+		} `json:"frameworks"`
+	} `json:"project"`
+}
+
+// ParseProjectAssets 解析obj/project.assets.json 多个目标框架的依赖合并为同一依赖图
+func ParseProjectAssets(file *model.File) *model.DepGraph {
+
+	assets := ProjectAssets{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := json.NewDecoder(reader).Decode(&assets); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	root := &model.DepGraph{Name: assets.Project.Restore.ProjectName, Path: file.Relpath()}
+
+	// 直接引用的包 key:小写包名
+	direct := map[string]bool{}
+	for _, fw := range assets.Project.Frameworks {
+		for name := range fw.Dependencies {
+			direct[strings.ToLower(name)] = true
+		}
+	}
+
+	_dep := model.NewDepGraphMap(func(s ...string) string {
+		return strings.ToLower(strings.Join(s, ":"))
+	}, func(s ...string) *model.DepGraph {
+		return &model.DepGraph{Name: s[0], Version: s[1]}
+	}).LoadOrStore
+
+	for _, target := range sortedKeys(assets.Targets) {
+
+		libs := assets.Targets[target]
+
+		// 当前框架下的包 key:小写包名
+		nodes := map[string]*model.DepGraph{}
+		for _, lib := range sortedKeys(libs) {
+			// 例 Newtonsoft.Json/13.0.1
+			name, version, _ := strings.Cut(lib, "/")
+			// 项目引用为本地项目 版本号留空
+			if libs[lib].Type == "project" {
+				version = ""
+				direct[strings.ToLower(name)] = true
+			}
+			nodes[strings.ToLower(name)] = _dep(name, version)
+		}
+
+		for _, lib := range sortedKeys(libs) {
+			name, _, _ := strings.Cut(lib, "/")
+			node := nodes[strings.ToLower(name)]
+			for _, sub := range sortedKeys(libs[lib].Dependencies) {
+				node.AppendChild(nodes[strings.ToLower(sub)])
+			}
+			if direct[strings.ToLower(name)] {
+				root.AppendChild(node)
+			}
+		}
+	}
+
+	return root
+}
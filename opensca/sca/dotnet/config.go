@@ -0,0 +1,40 @@
+package dotnet
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// ParsePackagesConfig 解析packages.config
+// <package id="Newtonsoft.Json" version="13.0.1" targetFramework="net48" developmentDependency="true" />
+// root: 所属项目的依赖图 不存在时为nil
+func ParsePackagesConfig(file *model.File, root *model.DepGraph) *model.DepGraph {
+
+	config := struct {
+		Packages []struct {
+			Id          string `xml:"id,attr"`
+			Version     string `xml:"version,attr"`
+			Development bool   `xml:"developmentDependency,attr"`
+		} `xml:"package"`
+	}{}
+
+	file.OpenReader(func(reader io.Reader) {
+		if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	if root == nil {
+		root = &model.DepGraph{Path: file.Relpath()}
+	}
+
+	// packages.config中记录了全部依赖 没有依赖关系
+	for _, p := range config.Packages {
+		root.AppendChild(&model.DepGraph{Name: p.Id, Version: p.Version, Develop: p.Development})
+	}
+
+	return root
+}
@@ -0,0 +1,84 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// PackagesLock packages.lock.json文件结构
+type PackagesLock struct {
+	Version int `json:"version"`
+	// key:目标框架 例 net6.0 | net6.0/win-x64
+	Dependencies map[string]map[string]struct {
+		// Direct | Transitive | Project | CentralTransitive
+		Type     string `json:"type"`
+		Resolved string `json:"resolved"`
+		// key:依赖的包名 value:版本约束
+		Dependencies map[string]string `json:"dependencies"`
+	} `json:"dependencies"`
+}
+
+// sortedKeys 排序后的key
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParsePackagesLock 解析packages.lock.json 多个目标框架的依赖合并为同一依赖图
+// name: 项目名
+func ParsePackagesLock(file *model.File, name string) *model.DepGraph {
+
+	lock := PackagesLock{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	root := &model.DepGraph{Name: name, Path: file.Relpath()}
+
+	_dep := model.NewDepGraphMap(func(s ...string) string {
+		return strings.ToLower(strings.Join(s, ":"))
+	}, func(s ...string) *model.DepGraph {
+		return &model.DepGraph{Name: s[0], Version: s[1]}
+	}).LoadOrStore
+
+	for _, fw := range sortedKeys(lock.Dependencies) {
+
+		pkgs := lock.Dependencies[fw]
+
+		// 当前框架下的包 key:小写包名
+		nodes := map[string]*model.DepGraph{}
+		for _, n := range sortedKeys(pkgs) {
+			p := pkgs[n]
+			// 项目引用为本地项目 版本号留空
+			if p.Type == "Project" {
+				nodes[strings.ToLower(n)] = _dep(n, "")
+			} else {
+				nodes[strings.ToLower(n)] = _dep(n, p.Resolved)
+			}
+		}
+
+		for _, n := range sortedKeys(pkgs) {
+			p := pkgs[n]
+			node := nodes[strings.ToLower(n)]
+			for _, sub := range sortedKeys(p.Dependencies) {
+				node.AppendChild(nodes[strings.ToLower(sub)])
+			}
+			if p.Type == "Direct" || p.Type == "Project" {
+				root.AppendChild(node)
+			}
+		}
+	}
+
+	return root
+}
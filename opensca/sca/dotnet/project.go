@@ -0,0 +1,111 @@
+package dotnet
+
+import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// Project *.csproj/*.fsproj/*.vbproj/Directory.Packages.props文件结构
+type Project struct {
+	File       *model.File `xml:"-"`
+	ItemGroups []struct {
+		PackageReference []PackageItem `xml:"PackageReference"`
+		// Directory.Packages.props中集中管理的版本
+		PackageVersion []PackageItem `xml:"PackageVersion"`
+	} `xml:"ItemGroup"`
+}
+
+// PackageItem 引用的包 例 <PackageReference Include="Newtonsoft.Json" Version="13.0.1" />
+type PackageItem struct {
+	Include string `xml:"Include,attr"`
+	Update  string `xml:"Update,attr"`
+	Version string `xml:"Version,attr"`
+	// 覆盖集中管理的版本
+	VersionOverride string `xml:"VersionOverride,attr"`
+	// 子元素形式的版本 例 <Version>13.0.1</Version>
+	VersionElement string `xml:"Version"`
+}
+
+// name 包名
+func (item PackageItem) name() string {
+	if item.Include != "" {
+		return item.Include
+	}
+	return item.Update
+}
+
+// version 包版本
+func (item PackageItem) version() string {
+	for _, v := range []string{item.VersionOverride, item.Version, item.VersionElement} {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ReadProject 读取项目文件
+func ReadProject(file *model.File) *Project {
+	p := &Project{File: file}
+	file.OpenReader(func(reader io.Reader) {
+		if err := xml.NewDecoder(reader).Decode(p); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+	return p
+}
+
+// projectName 项目名 例 src/App/App.csproj => App
+func projectName(file *model.File) string {
+	name := filepath.Base(file.Relpath())
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// nugetVersion 解析版本约束 返回版本号及是否为范围
+// 例 13.0.1 | [13.0.1] | [1.0,2.0) | 1.*
+func nugetVersion(version string) (string, bool) {
+	version = strings.TrimSpace(version)
+	if strings.HasPrefix(version, "[") && strings.HasSuffix(version, "]") && !strings.Contains(version, ",") {
+		return strings.TrimSpace(version[1 : len(version)-1]), false
+	}
+	return version, strings.ContainsAny(version, "[](),*")
+}
+
+// ParseProject 解析项目文件中的PackageReference
+// props: 项目所在目录或上级目录中的Directory.Packages.props 不存在时为nil
+func ParseProject(file, props *model.File) *model.DepGraph {
+
+	// 集中管理的版本 key:小写包名
+	central := map[string]string{}
+	if props != nil {
+		for _, group := range ReadProject(props).ItemGroups {
+			for _, item := range group.PackageVersion {
+				central[strings.ToLower(item.name())] = item.version()
+			}
+		}
+	}
+
+	root := &model.DepGraph{Name: projectName(file), Path: file.Relpath()}
+
+	for _, group := range ReadProject(file).ItemGroups {
+		for _, item := range group.PackageReference {
+			name := item.name()
+			if name == "" {
+				continue
+			}
+			v := item.version()
+			if v == "" {
+				v = central[strings.ToLower(name)]
+			}
+			version, isRange := nugetVersion(v)
+			root.AppendChild(&model.DepGraph{Name: name, Version: version, VersionRange: isRange})
+		}
+	}
+
+	return root
+}
@@ -0,0 +1,96 @@
+package dotnet
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_DotNet
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.DotNetPackagesLock(relpath) ||
+		filter.DotNetProjectAssets(relpath) ||
+		filter.DotNetProject(relpath) ||
+		filter.DotNetPackagesProps(relpath) ||
+		filter.DotNetPackagesConfig(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// map[dir]*File
+	locks := map[string]*model.File{}
+	// key:obj所在的项目目录
+	assets := map[string]*model.File{}
+	props := map[string]*model.File{}
+	configs := map[string]*model.File{}
+	var projects []*model.File
+	for _, f := range files {
+		dir := filepath.Dir(f.Relpath())
+		if filter.DotNetPackagesLock(f.Relpath()) {
+			locks[dir] = f
+		}
+		if filter.DotNetProjectAssets(f.Relpath()) {
+			assets[filepath.Dir(dir)] = f
+		}
+		if filter.DotNetPackagesProps(f.Relpath()) {
+			props[dir] = f
+		}
+		if filter.DotNetPackagesConfig(f.Relpath()) {
+			configs[dir] = f
+		}
+		if filter.DotNetProject(f.Relpath()) {
+			projects = append(projects, f)
+		}
+	}
+
+	// nearestProps 项目所在目录或最近的上级目录中的Directory.Packages.props
+	nearestProps := func(dir string) *model.File {
+		for {
+			if p, ok := props[dir]; ok {
+				return p
+			}
+			up := filepath.Dir(dir)
+			if up == dir {
+				return nil
+			}
+			dir = up
+		}
+	}
+
+	// 以项目为单位解析 packages.lock.json > obj/project.assets.json > 项目文件及packages.config
+	for _, f := range projects {
+		dir := filepath.Dir(f.Relpath())
+		if lock, ok := locks[dir]; ok {
+			call(lock, ParsePackagesLock(lock, projectName(f)))
+		} else if a, ok := assets[dir]; ok {
+			call(a, ParseProjectAssets(a))
+		} else {
+			root := ParseProject(f, nearestProps(dir))
+			if config, ok := configs[dir]; ok {
+				root = ParsePackagesConfig(config, root)
+			}
+			call(f, root)
+		}
+		delete(locks, dir)
+		delete(assets, dir)
+		delete(configs, dir)
+	}
+
+	// 不存在项目文件时单独解析
+	for _, f := range locks {
+		call(f, ParsePackagesLock(f, ""))
+	}
+	for _, f := range assets {
+		call(f, ParseProjectAssets(f))
+	}
+	for _, f := range configs {
+		call(f, ParsePackagesConfig(f, nil))
+	}
+}
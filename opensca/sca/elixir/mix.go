@@ -0,0 +1,149 @@
+package elixir
+
+import (
+	"io"
+	"regexp"
+	"sort"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/erlang"
+)
+
+// MixDep mix.exs中声明的依赖
+type MixDep struct {
+	Name string
+	// 仅在dev/test环境使用 例 only: [:dev, :test]
+	Develop bool
+}
+
+// MixExs mix.exs中的项目信息
+type MixExs struct {
+	App  string
+	Deps []*MixDep
+}
+
+var (
+	mixAppRe  = regexp.MustCompile(`app:\s*:(\w+)`)
+	mixDepsRe = regexp.MustCompile(`defp?\s+deps\s*(?:\(\s*\))?\s*do`)
+)
+
+// ReadMixExs 读取mix.exs中deps函数返回的依赖列表
+func ReadMixExs(file *model.File) *MixExs {
+
+	mix := &MixExs{}
+
+	var data []byte
+	file.OpenReader(func(reader io.Reader) {
+		data, _ = io.ReadAll(reader)
+	})
+
+	if m := mixAppRe.FindSubmatch(data); m != nil {
+		mix.App = string(m[1])
+	}
+
+	loc := mixDepsRe.FindIndex(data)
+	if loc == nil {
+		return mix
+	}
+
+	list, err := erlang.ParseTerm(string(data[loc[1]:]))
+	if err != nil {
+		logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		return mix
+	}
+
+	// {:phoenix, "~> 1.7"} | {:credo, "~> 1.7", only: [:dev, :test], runtime: false}
+	items, _ := list.([]any)
+	for _, item := range items {
+		t, ok := item.(erlang.Tuple)
+		if !ok || len(t) == 0 {
+			continue
+		}
+		dep := &MixDep{Name: erlang.Str(t[0])}
+		if only, ok := erlang.Keyword(t[len(t)-1], "only"); ok {
+			envs, ok := only.([]any)
+			if !ok {
+				envs = []any{only}
+			}
+			dep.Develop = true
+			for _, env := range envs {
+				if erlang.Str(env) == "prod" {
+					dep.Develop = false
+				}
+			}
+		}
+		mix.Deps = append(mix.Deps, dep)
+	}
+
+	return mix
+}
+
+// ParseMixLock 解析mix.lock
+// %{"jason": {:hex, :jason, "1.4.1", "hash", [:mix], [{:decimal, "~> 1.0", [hex: :decimal, optional: true]}], "hexpm", "hash"}}
+// mix: 同目录下的mix.exs 不存在时为nil
+func ParseMixLock(file *model.File, mix *MixExs) *model.DepGraph {
+
+	var lock erlang.Map
+	file.OpenReader(func(reader io.Reader) {
+		terms, err := erlang.ParseTerms(reader)
+		if err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+		if len(terms) > 0 {
+			lock, _ = terms[0].(erlang.Map)
+		}
+	})
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	names := make([]string, 0, len(lock))
+	for name := range lock {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// 记录组件 key:mix.lock中的应用名
+	depMap := map[string]*model.DepGraph{}
+	for _, name := range names {
+		dep := &model.DepGraph{Name: name}
+		if t, ok := lock[name].(erlang.Tuple); ok && len(t) > 2 && erlang.Str(t[0]) == "hex" {
+			// hex中的包名可能与应用名不同
+			dep.Name = erlang.Str(t[1])
+			dep.Version = erlang.Str(t[2])
+		}
+		depMap[name] = dep
+	}
+
+	// 记录依赖关系 git依赖中没有依赖信息
+	for _, name := range names {
+		t, ok := lock[name].(erlang.Tuple)
+		if !ok || len(t) < 6 || erlang.Str(t[0]) != "hex" {
+			continue
+		}
+		subs, _ := t[5].([]any)
+		for _, sub := range subs {
+			if st, ok := sub.(erlang.Tuple); ok && len(st) > 0 {
+				depMap[name].AppendChild(depMap[erlang.Str(st[0])])
+			}
+		}
+	}
+
+	if mix != nil && len(mix.Deps) > 0 {
+		root.Name = mix.App
+		for _, d := range mix.Deps {
+			if dep, ok := depMap[d.Name]; ok {
+				dep.Develop = d.Develop
+				root.AppendChild(dep)
+			}
+		}
+	} else {
+		for _, name := range names {
+			if len(depMap[name].Parents) == 0 {
+				root.AppendChild(depMap[name])
+			}
+		}
+	}
+
+	return root
+}
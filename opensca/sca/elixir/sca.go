@@ -0,0 +1,40 @@
+package elixir
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_Elixir
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.ElixirMixLock(relpath) || filter.ElixirMixExs(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// map[dir]*File
+	mixExs := map[string]*model.File{}
+	for _, f := range files {
+		if filter.ElixirMixExs(f.Relpath()) {
+			mixExs[filepath.Dir(f.Relpath())] = f
+		}
+	}
+
+	for _, f := range files {
+		if filter.ElixirMixLock(f.Relpath()) {
+			var mix *MixExs
+			if exs, ok := mixExs[filepath.Dir(f.Relpath())]; ok {
+				mix = ReadMixExs(exs)
+			}
+			call(f, ParseMixLock(f, mix))
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package erlang
+
+import (
+	"io"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// readTerms 读取文件中的Erlang项
+func readTerms(file *model.File) []any {
+	var terms []any
+	file.OpenReader(func(reader io.Reader) {
+		var err error
+		terms, err = ParseTerms(reader)
+		if err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+	return terms
+}
+
+// RebarConfig rebar.config中声明的依赖
+type RebarConfig struct {
+	// 直接依赖
+	Deps []string
+}
+
+// depNames 依赖列表中的依赖名 例 [cowboy, {jsx, "3.1.0"}, {lager, {git, "url", {tag, "3.9.2"}}}]
+func depNames(list any) []string {
+	var names []string
+	items, _ := list.([]any)
+	for _, item := range items {
+		switch d := item.(type) {
+		case Atom:
+			names = append(names, string(d))
+		case Tuple:
+			if len(d) > 0 {
+				names = append(names, Str(d[0]))
+			}
+		}
+	}
+	return names
+}
+
+// ReadRebarConfig 读取rebar.config
+func ReadRebarConfig(file *model.File) *RebarConfig {
+	config := &RebarConfig{}
+	for _, term := range readTerms(file) {
+		if t, ok := term.(Tuple); ok && len(t) == 2 && Str(t[0]) == "deps" {
+			config.Deps = append(config.Deps, depNames(t[1])...)
+		}
+	}
+	return config
+}
+
+// ParseRebarLock 解析rebar.lock 兼容v1/v2格式
+// [{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.9.0">>},0}].
+// {"1.2.0",[{<<"lager">>,{git,"https://xxx",{ref,"xxx"}},0}]}.
+// [{pkg_hash,[...]}].
+// config: 同目录下的rebar.config 不存在时为nil
+func ParseRebarLock(file *model.File, config *RebarConfig) *model.DepGraph {
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	terms := readTerms(file)
+	if len(terms) == 0 {
+		return root
+	}
+
+	// v2格式为{版本,依赖列表}
+	locked := terms[0]
+	if t, ok := locked.(Tuple); ok && len(t) == 2 {
+		locked = t[1]
+	}
+
+	direct := map[string]bool{}
+	if config != nil {
+		for _, name := range config.Deps {
+			direct[name] = true
+		}
+	}
+
+	// rebar.lock中没有依赖关系 仅记录依赖层级 0为直接依赖
+	items, _ := locked.([]any)
+	for _, item := range items {
+		t, ok := item.(Tuple)
+		if !ok || len(t) < 2 {
+			continue
+		}
+		dep := &model.DepGraph{Name: Str(t[0])}
+		if source, ok := t[1].(Tuple); ok && len(source) > 0 {
+			switch Str(source[0]) {
+			case "pkg":
+				// {pkg,<<"name">>,<<"version">>}
+				if len(source) > 2 {
+					dep.Name = Str(source[1])
+					dep.Version = Str(source[2])
+				}
+			case "git":
+				// {git,"url",{tag,"1.0.0"}} 仅tag可作为版本号
+				if len(source) > 2 {
+					if ref, ok := source[2].(Tuple); ok && len(ref) == 2 && Str(ref[0]) == "tag" {
+						dep.Version = Str(ref[1])
+					}
+				}
+			}
+		}
+		// 层级为0或在rebar.config中声明的为直接依赖
+		level := ""
+		if len(t) > 2 {
+			level = Str(t[2])
+		}
+		dep.Indirect = level != "0" && !direct[Str(t[0])]
+		root.AppendChild(dep)
+	}
+
+	return root
+}
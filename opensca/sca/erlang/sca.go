@@ -2,7 +2,7 @@ package erlang
 
 import (
 	"context"
-	"regexp"
+	"path/filepath"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
@@ -15,28 +15,26 @@ func (sca Sca) Language() model.Language {
 }
 
 func (sca Sca) Filter(relpath string) bool {
-	return filter.ErlangRebarLock(relpath)
+	return filter.ErlangRebarLock(relpath) || filter.ErlangRebarConfig(relpath)
 }
 
 func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// map[dir]*File
+	configs := map[string]*model.File{}
 	for _, f := range files {
-		if sca.Filter(f.Relpath()) {
-			call(f, ParseRebarLock(f))
+		if filter.ErlangRebarConfig(f.Relpath()) {
+			configs[filepath.Dir(f.Relpath())] = f
 		}
 	}
-}
 
-func ParseRebarLock(file *model.File) *model.DepGraph {
-	reg := regexp.MustCompile(`<<"([\w\d]+)">>\S*?pkg,<<"[\w\d]+">>,<<"([.\d]+)">>`)
-	root := &model.DepGraph{Path: file.Relpath()}
-	file.ReadLine(func(line string) {
-		match := reg.FindStringSubmatch(line)
-		if len(match) > 2 {
-			root.AppendChild(&model.DepGraph{
-				Name:    match[1],
-				Version: match[2],
-			})
+	for _, f := range files {
+		if filter.ErlangRebarLock(f.Relpath()) {
+			var config *RebarConfig
+			if c, ok := configs[filepath.Dir(f.Relpath())]; ok {
+				config = ReadRebarConfig(c)
+			}
+			call(f, ParseRebarLock(f, config))
 		}
-	})
-	return root
+	}
 }
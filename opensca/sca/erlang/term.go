@@ -0,0 +1,369 @@
+package erlang
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Atom 原子 例 pkg | :hex | true | 数字
+type Atom string
+
+// Tuple 元组 例 {pkg,<<"cowboy">>,<<"2.9.0">>}
+type Tuple []any
+
+// Map 映射 例 #{k => v} | %{"k" => v} | %{k: v} key为字符串或原子的字面值
+type Map map[string]any
+
+// termParser Erlang/Elixir字面量解析 用于rebar.lock、rebar.config、mix.lock及mix.exs
+type termParser struct {
+	s []rune
+	i int
+}
+
+// ParseTerms 解析以.分隔的Erlang项或单个Elixir字面量
+// 元组=>Tuple 列表=>[]any 映射=>Map 字符串/二进制=>string 原子/数字=>Atom
+func ParseTerms(reader io.Reader) ([]any, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return parseTerms(string(data))
+}
+
+func parseTerms(s string) ([]any, error) {
+	p := &termParser{s: []rune(s)}
+	var terms []any
+	for {
+		p.skip()
+		if p.eof() {
+			return terms, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return terms, err
+		}
+		terms = append(terms, v)
+		p.skip()
+		if p.peek(0) == '.' {
+			p.i++
+		}
+	}
+}
+
+// ParseTerm 从文本中指定位置解析单个字面量
+func ParseTerm(s string) (any, error) {
+	p := &termParser{s: []rune(s)}
+	p.skip()
+	return p.value()
+}
+
+func (p *termParser) eof() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *termParser) peek(n int) rune {
+	if p.i+n < len(p.s) {
+		return p.s[p.i+n]
+	}
+	return 0
+}
+
+func (p *termParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("term offset %d: %s", p.i, fmt.Sprintf(format, args...))
+}
+
+// skip 跳过空白及注释 Erlang注释为% Elixir注释为#
+func (p *termParser) skip() {
+	for !p.eof() {
+		c := p.peek(0)
+		switch {
+		case unicode.IsSpace(c):
+			p.i++
+		case (c == '%' || c == '#') && p.peek(1) != '{':
+			for !p.eof() && p.peek(0) != '\n' {
+				p.i++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func isWordRune(c rune) bool {
+	return c == '_' || c == '@' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// word 读取原子/数字 模块名中的.会被保留 例 Mix.Project
+func (p *termParser) word() string {
+	start := p.i
+	for !p.eof() {
+		c := p.peek(0)
+		if isWordRune(c) || ((c == '.' || c == '-' || c == '+') && p.i > start && isWordRune(p.peek(1))) {
+			p.i++
+			continue
+		}
+		if (c == '?' || c == '!') && p.i > start {
+			p.i++
+		}
+		break
+	}
+	return string(p.s[start:p.i])
+}
+
+// str 读取带引号的字符串
+func (p *termParser) str(quote rune) (string, error) {
+	p.i++
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.peek(0)
+		p.i++
+		switch c {
+		case '\\':
+			if !p.eof() {
+				sb.WriteRune(p.peek(0))
+				p.i++
+			}
+		case quote:
+			return sb.String(), nil
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// key 关键字列表及映射中的key 例 only: | "jason":
+func (p *termParser) key() (string, bool) {
+	save := p.i
+	var k string
+	switch c := p.peek(0); {
+	case c == '"':
+		s, err := p.str('"')
+		if err != nil {
+			p.i = save
+			return "", false
+		}
+		k = s
+	case isWordRune(c) && !unicode.IsDigit(c):
+		k = p.word()
+	default:
+		return "", false
+	}
+	if p.peek(0) == ':' && p.peek(1) != ':' && (unicode.IsSpace(p.peek(1)) || p.peek(1) == 0) {
+		p.i++
+		return k, true
+	}
+	p.i = save
+	return "", false
+}
+
+// seq 读取以,分隔的元素直到close 关键字参数合并为列表
+func (p *termParser) seq(close rune, tuple bool) ([]any, error) {
+	var items []any
+	var keywords []any
+	for {
+		p.skip()
+		if p.eof() {
+			return nil, p.errorf("missing %c", close)
+		}
+		if p.peek(0) == close {
+			p.i++
+			break
+		}
+		if k, ok := p.key(); ok {
+			p.skip()
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			keywords = append(keywords, Tuple{Atom(k), v})
+		} else {
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		p.skip()
+		switch p.peek(0) {
+		case ',', '|':
+			p.i++
+		case close:
+		default:
+			return nil, p.errorf("unexpected %q", p.peek(0))
+		}
+	}
+	if len(keywords) > 0 {
+		if tuple {
+			items = append(items, keywords)
+		} else {
+			items = append(items, keywords...)
+		}
+	}
+	return items, nil
+}
+
+// mapping 读取映射 例 #{k => v} | %{k: v}
+func (p *termParser) mapping() (Map, error) {
+	m := Map{}
+	for {
+		p.skip()
+		if p.eof() {
+			return nil, p.errorf("missing }")
+		}
+		if p.peek(0) == '}' {
+			p.i++
+			return m, nil
+		}
+		var key string
+		if k, ok := p.key(); ok {
+			key = k
+		} else {
+			kv, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			key = fmt.Sprint(kv)
+			p.skip()
+			if p.peek(0) == '=' && p.peek(1) == '>' || p.peek(0) == ':' && p.peek(1) == '=' {
+				p.i += 2
+			} else {
+				return nil, p.errorf("missing =>")
+			}
+		}
+		p.skip()
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+		p.skip()
+		if p.peek(0) == ',' {
+			p.i++
+		}
+	}
+}
+
+// value 读取单个字面量
+func (p *termParser) value() (any, error) {
+	p.skip()
+	c := p.peek(0)
+	switch {
+	case c == '{':
+		p.i++
+		items, err := p.seq('}', true)
+		return Tuple(items), err
+	case c == '[':
+		p.i++
+		return p.seq(']', false)
+	case (c == '%' || c == '#') && p.peek(1) == '{':
+		p.i += 2
+		return p.mapping()
+	case c == '<' && p.peek(1) == '<':
+		// 二进制 例 <<"cowboy">>
+		p.i += 2
+		var sb strings.Builder
+		for {
+			p.skip()
+			if p.eof() {
+				return nil, p.errorf("missing >>")
+			}
+			if p.peek(0) == '>' && p.peek(1) == '>' {
+				p.i += 2
+				return sb.String(), nil
+			}
+			switch p.peek(0) {
+			case '"':
+				s, err := p.str('"')
+				if err != nil {
+					return nil, err
+				}
+				sb.WriteString(s)
+			case ',':
+				p.i++
+			case '$':
+				// 字符 例 <<$a, $b>>
+				p.i++
+				if p.peek(0) == '\\' {
+					p.i++
+				}
+				if !p.eof() {
+					sb.WriteRune(p.peek(0))
+					p.i++
+				}
+			case '-', '+', '/', ':':
+				// 符号及段类型 例 <<-1:8/signed-integer>>
+				p.i++
+			default:
+				if p.word() == "" {
+					return nil, p.errorf("unexpected %q", p.peek(0))
+				}
+			}
+		}
+	case c == '"':
+		return p.str('"')
+	case c == '\'':
+		s, err := p.str('\'')
+		return Atom(s), err
+	case c == ':' && p.peek(1) == '"':
+		p.i++
+		s, err := p.str('"')
+		return Atom(s), err
+	case c == ':' && isWordRune(p.peek(1)):
+		p.i++
+		return Atom(p.word()), nil
+	case c == '-' || isWordRune(c):
+		if c == '-' {
+			p.i++
+		}
+		w := p.word()
+		if c == '-' {
+			w = "-" + w
+		}
+		// 函数调用 例 System.get_env("VERSION")
+		if p.peek(0) == '(' {
+			depth := 0
+			for !p.eof() {
+				switch p.peek(0) {
+				case '(':
+					depth++
+				case ')':
+					depth--
+				}
+				p.i++
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		return Atom(w), nil
+	}
+	if p.eof() {
+		return nil, p.errorf("unexpected end")
+	}
+	return nil, p.errorf("unexpected %q", c)
+}
+
+// Str 字符串或原子的字面值
+func Str(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case Atom:
+		return string(s)
+	}
+	return ""
+}
+
+// Keyword 在关键字列表或proplist中查找key对应的值
+func Keyword(list any, key string) (any, bool) {
+	items, _ := list.([]any)
+	for _, item := range items {
+		if t, ok := item.(Tuple); ok && len(t) == 2 && Str(t[0]) == key {
+			return t[1], true
+		}
+	}
+	return nil, false
+}
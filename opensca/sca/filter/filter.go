@@ -1,8 +1,12 @@
 package filter
 
 import (
+	"bytes"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
+	"unicode"
 
 	gitignore "github.com/sabhiram/go-gitignore"
 )
@@ -28,6 +32,7 @@ var (
 		return strings.HasSuffix(filename, "package.json")
 	}
 	JavaScriptYarnLock = filterFunc(strings.HasSuffix, "yarn.lock")
+	JavaScriptPnpmLock = filterFunc(strings.HasSuffix, "pnpm-lock.yaml")
 )
 
 var (
@@ -37,26 +42,43 @@ var (
 
 var (
 	RubyGemfileLock = filterFunc(strings.HasSuffix, "Gemfile.lock", "gems.locked")
+	RubyGemfile     = filterFunc(strings.HasSuffix, "Gemfile", "gems.rb")
+	RubyGemspec     = filterFunc(strings.HasSuffix, ".gemspec")
 )
 
 var (
-	GoMod     = filterFunc(strings.HasSuffix, "go.mod")
-	GoSum     = filterFunc(strings.HasSuffix, "go.sum", "go.work.sum")
-	GoPkgToml = filterFunc(strings.HasSuffix, "Gopkg.toml")
-	GoPkgLock = filterFunc(strings.HasSuffix, "Gopkg.lock")
+	GoMod           = filterFunc(strings.HasSuffix, "go.mod")
+	GoSum           = filterFunc(strings.HasSuffix, "go.sum", "go.work.sum")
+	GoPkgToml       = filterFunc(strings.HasSuffix, "Gopkg.toml")
+	GoPkgLock       = filterFunc(strings.HasSuffix, "Gopkg.lock")
+	GoWork          = filterFunc(strings.HasSuffix, "go.work")
+	GoVendorModules = func(filename string) bool {
+		return filepath.Base(filename) == "modules.txt" && filepath.Base(filepath.Dir(filename)) == "vendor"
+	}
 )
 
 var (
 	RustCargoLock = filterFunc(strings.HasSuffix, "Cargo.lock")
+	RustCargoToml = filterFunc(strings.HasSuffix, "Cargo.toml")
+)
+
+var (
+	ErlangRebarLock   = filterFunc(strings.HasSuffix, "rebar.lock")
+	ErlangRebarConfig = filterFunc(strings.HasSuffix, "rebar.config")
 )
 
 var (
-	ErlangRebarLock = filterFunc(strings.HasSuffix, "rebar.lock")
+	ElixirMixLock = filterFunc(strings.HasSuffix, "mix.lock")
+	ElixirMixExs  = filterFunc(strings.HasSuffix, "mix.exs")
 )
 
 var (
 	GroovyFile   = filterFunc(strings.HasSuffix, ".groovy")
 	GroovyGradle = filterFunc(strings.HasSuffix, ".gradle", ".gradle.kts")
+	// GroovyVersionCatalog gradle版本目录 例 gradle/libs.versions.toml
+	GroovyVersionCatalog = filterFunc(strings.HasSuffix, ".versions.toml")
+	// GroovyGradleLockfile gradle.lockfile | buildscript-gradle.lockfile
+	GroovyGradleLockfile = filterFunc(strings.HasSuffix, "gradle.lockfile")
 )
 
 var (
@@ -68,6 +90,65 @@ var (
 			filterFunc(strings.Contains, "requirements")(filepath.Base(filename)) && !filterFunc(strings.Contains, "test")(filepath.Base(filename))
 	}
 	PythonRequirementsIn = filterFunc(strings.HasSuffix, "requirements.in")
+	PythonPyproject      = filterFunc(strings.HasSuffix, "pyproject.toml")
+	PythonPoetryLock     = filterFunc(strings.HasSuffix, "poetry.lock")
+	PythonUvLock         = filterFunc(strings.HasSuffix, "uv.lock")
+	PythonPdmLock        = filterFunc(strings.HasSuffix, "pdm.lock")
+	PythonMetadata       = func(filename string) bool {
+		return filepath.Base(filename) == "METADATA" && strings.HasSuffix(filepath.Dir(filename), ".dist-info")
+	}
+	PythonPkgInfo = func(filename string) bool {
+		return strings.HasSuffix(filename, ".egg-info") ||
+			(filepath.Base(filename) == "PKG-INFO" && strings.HasSuffix(filepath.Dir(filename), ".egg-info"))
+	}
+	PythonWheel = filterFunc(strings.HasSuffix, ".whl")
+)
+
+var (
+	DotNetPackagesLock   = filterFunc(strings.HasSuffix, "packages.lock.json")
+	DotNetProjectAssets  = filterFunc(strings.HasSuffix, "project.assets.json")
+	DotNetProject        = filterFunc(strings.HasSuffix, ".csproj", ".fsproj", ".vbproj")
+	DotNetPackagesProps  = filterFunc(strings.HasSuffix, "Directory.Packages.props")
+	DotNetPackagesConfig = filterFunc(strings.HasSuffix, "packages.config")
+)
+
+var (
+	SwiftPackageResolved = filterFunc(strings.HasSuffix, "Package.resolved")
+	SwiftPodfileLock     = filterFunc(strings.HasSuffix, "Podfile.lock")
+)
+
+var (
+	DartPubspecLock = filterFunc(strings.HasSuffix, "pubspec.lock")
+	DartPubspec     = filterFunc(strings.HasSuffix, "pubspec.yaml")
+)
+
+var (
+	// ScalaSbt sbt构建文件 不含project目录下的插件配置
+	ScalaSbt = func(filename string) bool {
+		return strings.HasSuffix(filename, ".sbt") && filepath.Base(filepath.Dir(filename)) != "project"
+	}
+	// ScalaProject project目录下的scala构建定义 例 project/Dependencies.scala
+	ScalaProject = func(filename string) bool {
+		return strings.HasSuffix(filename, ".scala") && filepath.Base(filepath.Dir(filename)) == "project"
+	}
+	ScalaSbtLock = filterFunc(strings.HasSuffix, "build.sbt.lock")
+)
+
+var (
+	ClojureLeinProject = filterFunc(strings.HasSuffix, "project.clj")
+	ClojureDepsEdn     = filterFunc(strings.HasSuffix, "deps.edn")
+)
+
+var (
+	BazelModule       = filterFunc(strings.HasSuffix, "MODULE.bazel")
+	BazelMavenInstall = filterFunc(strings.HasSuffix, "maven_install.json")
+)
+
+var (
+	CppConanLock          = filterFunc(strings.HasSuffix, "conan.lock")
+	CppConanFile          = filterFunc(strings.HasSuffix, "conanfile.txt")
+	CppVcpkgJson          = filterFunc(strings.HasSuffix, "vcpkg.json")
+	CppVcpkgConfiguration = filterFunc(strings.HasSuffix, "vcpkg-configuration.json")
 )
 
 var (
@@ -79,6 +160,45 @@ var (
 	// SbomRdf  = filterFunc(strings.HasSuffix, ".rdf")
 )
 
+var (
+	// Binary 可能为可执行文件 无后缀、.exe、.bin或后缀中不含字母(例 app-1.2.3) 需以BinaryFile确认
+	Binary = func(filename string) bool {
+		ext := filepath.Ext(filename)
+		if ext == "" || ext == ".exe" || ext == ".bin" {
+			return true
+		}
+		return strings.IndexFunc(ext[1:], unicode.IsLetter) == -1
+	}
+)
+
+// executableMagic 可执行文件头 ELF | PE | Mach-O(32/64位 大小端) | Mach-O通用二进制
+var executableMagic = [][]byte{
+	[]byte("\x7fELF"),
+	[]byte("MZ"),
+	{0xfe, 0xed, 0xfa, 0xce},
+	{0xfe, 0xed, 0xfa, 0xcf},
+	{0xce, 0xfa, 0xed, 0xfe},
+	{0xcf, 0xfa, 0xed, 0xfe},
+	{0xca, 0xfe, 0xba, 0xbe},
+}
+
+// BinaryFile 根据文件头判断是否为可执行文件
+func BinaryFile(abspath string) bool {
+	f, err := os.Open(abspath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	head := make([]byte, 4)
+	n, _ := io.ReadFull(f, head)
+	for _, magic := range executableMagic {
+		if bytes.HasPrefix(head[:n], magic) {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	CompressFile = filterFunc(strings.HasSuffix,
 		".zip",
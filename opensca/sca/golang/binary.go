@@ -0,0 +1,48 @@
+package golang
+
+import (
+	"debug/buildinfo"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// ParseGoBinary 解析go二进制文件(ELF/PE/Mach-O)中嵌入的模块信息
+// 非go二进制文件返回nil
+func ParseGoBinary(file *model.File) *model.DepGraph {
+
+	info, err := buildinfo.ReadFile(file.Abspath())
+	if err != nil {
+		return nil
+	}
+
+	root := &model.DepGraph{Name: info.Main.Path, Version: binaryVersion(info.Main.Version), Path: file.Relpath()}
+	if root.Name == "" {
+		root.Name = info.Path
+	}
+
+	// 编译使用的go版本 例 go1.21.5 X:boringcrypto
+	if fields := strings.Fields(info.GoVersion); len(fields) > 0 && strings.HasPrefix(fields[0], "go") {
+		root.AppendChild(&model.DepGraph{Name: "stdlib", Version: "v" + strings.TrimPrefix(fields[0], "go")})
+	}
+
+	// 二进制中没有模块间的依赖关系 均作为直接依赖
+	for _, dep := range info.Deps {
+		var r *GoReplace
+		if dep.Replace != nil {
+			r = &GoReplace{Old: dep.Path, OldVersion: dep.Version, New: dep.Replace.Path, NewVersion: dep.Replace.Version}
+		}
+		name, version := replaced(r, dep.Path, dep.Version)
+		root.AppendChild(&model.DepGraph{Name: name, Version: binaryVersion(goVersion(version))})
+	}
+
+	return root
+}
+
+// binaryVersion 本地构建的模块版本为(devel)
+func binaryVersion(version string) string {
+	if version == "(devel)" {
+		return ""
+	}
+	return version
+}
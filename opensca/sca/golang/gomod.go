@@ -4,8 +4,11 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -14,91 +17,135 @@ import (
 )
 
 // ParseGomod 解析go.mod文件
+// 替换为本地目录的模块以本地模块的go.mod解析其依赖
+// 配置了GOPROXY时通过GOPROXY获取依赖模块的go.mod 并以最小版本选择(MVS)确定模块版本
 func ParseGomod(file *model.File) *model.DepGraph {
+	return parseGomodGraph(file, nil)
+}
 
-	root := &model.DepGraph{Path: file.Relpath()}
+// parseGomodGraph 解析go.mod构建依赖图
+// vendor: vendor/modules.txt中的模块 存在时模块版本以vendor中记录的为准
+func parseGomodGraph(file *model.File, vendor []*VendorModule) *model.DepGraph {
 
-	var require bool
-	var replace bool
+	mod := ReadGomod(file)
 
-	parseDepLine := func(line string) (name, version string) {
-		line = strings.TrimSpace(line)
-		words := strings.Fields(line)
-		if len(words) >= 2 {
-			name = strings.Trim(words[0], `'"`)
-			version = strings.TrimSuffix(words[1], "+incompatible")
+	root := &model.DepGraph{Name: mod.Module, Path: file.Relpath()}
+
+	// requires 模块的依赖 忽略被排除的模块版本
+	requires := func(m *GoMod) []*GoRequire {
+		var reqs []*GoRequire
+		for _, req := range m.Require {
+			if req.Path == mod.Module || slices.ContainsFunc(mod.Exclude, func(e *GoRequire) bool {
+				return e.Path == req.Path && e.Version == req.Version
+			}) {
+				continue
+			}
+			reqs = append(reqs, req)
 		}
-		return
+		return reqs
 	}
 
-	deps := map[string]string{}
+	// 替换为本地目录的模块 key:模块路径
+	locals := map[string]*GoMod{}
 
-	file.ReadLineNoComment(&model.CommentType{
-		Simple: "//",
-	}, func(line string) {
-
-		if strings.HasPrefix(line, "module") {
-			root.Name = strings.TrimSpace(strings.TrimPrefix(line, "module"))
-			return
+	// load 获取模块的go.mod 仅主模块中的替换生效 本地目录相对主模块所在目录
+	load := func(path, version string) *GoMod {
+		r := mod.replace(path, version)
+		if r == nil || !r.Local() {
+			name, version := replaced(r, path, version)
+			return goModOrigin(name, version)
 		}
-
-		if strings.HasPrefix(line, "require") {
-			require = true
-			line = strings.TrimPrefix(line, "require")
-			if strings.TrimSpace(line) == "" {
-				return
-			}
+		if m, ok := locals[path]; ok {
+			return m
 		}
+		m := &GoMod{File: localGomod(file, r.New)}
+		if m.File.Abspath() != "" {
+			m = ReadGomod(m.File)
+		}
+		locals[path] = m
+		return m
+	}
 
-		if strings.HasPrefix(line, "replace") {
-			replace = true
-			line = strings.TrimPrefix(line, "replace")
-			if strings.TrimSpace(line) == "" {
-				return
-			}
+	// 最小版本选择 同一模块选择依赖图中出现的最高版本
+	selected := map[string]string{}
+	// 记录模块各版本的依赖 key:path@version
+	reqMap := map[string][]*GoRequire{}
+	visited := map[string]bool{}
+	q := requires(mod)
+	for len(q) > 0 {
+		req := q[0]
+		q = q[1:]
+		key := req.Path + "@" + req.Version
+		if visited[key] {
+			continue
 		}
+		visited[key] = true
+		if v, ok := selected[req.Path]; !ok || goVersionLess(v, req.Version) {
+			selected[req.Path] = req.Version
+		}
+		if m := load(req.Path, req.Version); m != nil {
+			reqMap[key] = requires(m)
+			q = append(q, reqMap[key]...)
+		}
+	}
 
-		if strings.HasPrefix(line, ")") {
-			require = false
-			replace = false
-			return
+	// vendor中记录的版本即为实际构建使用的版本
+	for _, m := range vendor {
+		selected[m.Path] = m.Version
+	}
+
+	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
+		path, version := s[0], selected[s[0]]
+		if m, ok := locals[path]; ok {
+			return &model.DepGraph{Name: path, Path: m.File.Relpath()}
 		}
+		name, version := replaced(mod.replace(path, version), path, version)
+		return &model.DepGraph{Name: name, Version: goVersion(version)}
+	}).LoadOrStore
 
-		if require {
-			name, version := parseDepLine(line)
-			if name != "" {
-				deps[name] = version
-			}
-			return
+	direct := map[string]bool{}
+	for _, req := range requires(mod) {
+		dep := _dep(req.Path)
+		dep.Indirect = req.Indirect
+		root.AppendChild(dep)
+		direct[req.Path] = true
+	}
+
+	// go.mod中未记录的vendor模块(go1.17之前的go.mod不记录全部间接依赖)作为间接依赖
+	for _, m := range vendor {
+		if !direct[m.Path] {
+			dep := _dep(m.Path)
+			dep.Indirect = true
+			root.AppendChild(dep)
 		}
+	}
 
-		if replace {
-			line = strings.TrimSpace(line)
-			i := strings.Index(line, "=>")
-			if i == -1 {
-				return
-			}
-			old := strings.TrimSpace(line[:i])
-			delete(deps, old)
-			name, version := parseDepLine(line[i+2:])
-			if name != "" {
-				deps[name] = version
+	for path, version := range selected {
+		dep := _dep(path)
+		for _, req := range reqMap[path+"@"+version] {
+			if sub := _dep(req.Path); sub != dep {
+				dep.AppendChild(sub)
 			}
-			return
 		}
-
-	})
-
-	for name, version := range deps {
-		root.AppendChild(&model.DepGraph{
-			Name:    name,
-			Version: version,
-		})
 	}
 
 	return root
 }
 
+// localGomod 替换的本地模块go.mod 不存在时返回的文件路径为空
+// dir: 本地模块相对主模块的路径
+func localGomod(file *model.File, dir string) *model.File {
+	rel := path.Join(path.Dir(filepath.ToSlash(file.Relpath())), filepath.ToSlash(dir), "go.mod")
+	abs := filepath.Join(filepath.Dir(file.Abspath()), filepath.FromSlash(dir), "go.mod")
+	if filepath.IsAbs(dir) {
+		rel, abs = filepath.ToSlash(filepath.Join(dir, "go.mod")), filepath.Join(dir, "go.mod")
+	}
+	if _, err := os.Stat(abs); err != nil {
+		abs = ""
+	}
+	return model.NewFile(abs, rel)
+}
+
 // ParseGosum 解析go.sum文件
 func ParseGosum(file *model.File) *model.DepGraph {
 
@@ -129,6 +176,37 @@ func ParseGosum(file *model.File) *model.DepGraph {
 	return root
 }
 
+// ParseGomodWithGosum 解析go.mod 并以go.sum补全go.mod中未记录的模块(go1.17之前的go.mod不记录全部间接依赖)
+func ParseGomodWithGosum(modfile, sumfile *model.File) *model.DepGraph {
+
+	root := ParseGomod(modfile)
+
+	exist := map[string]bool{}
+	root.ForEachNode(func(p, n *model.DepGraph) bool {
+		exist[n.Name] = true
+		return true
+	})
+
+	// 仅记录下载了模块源码的记录 /go.mod记录的模块未必在构建列表中
+	versions := map[string]string{}
+	sumfile.ReadLine(func(line string) {
+		words := strings.Fields(line)
+		if len(words) < 2 || strings.HasSuffix(words[1], "/go.mod") || exist[words[0]] {
+			return
+		}
+		version := goVersion(words[1])
+		if v, ok := versions[words[0]]; !ok || goVersionLess(v, version) {
+			versions[words[0]] = version
+		}
+	})
+
+	for name, version := range versions {
+		root.AppendChild(&model.DepGraph{Name: name, Version: version, Indirect: true})
+	}
+
+	return root
+}
+
 // GoModGraph 调用 go mod graph 解析依赖
 func GoModGraph(ctx context.Context, modfile *model.File) *model.DepGraph {
 
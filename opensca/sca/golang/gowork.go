@@ -0,0 +1,90 @@
+package golang
+
+import (
+	"path/filepath"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// ParseGowork 解析go.work 将use引用的模块合并为同一依赖图
+// vendor: 工作区的vendor/modules.txt 不存在时为nil
+// gomod: 项目中的go.mod key:go.mod所在目录
+// 返回依赖图及合并的模块所在目录
+func ParseGowork(file, vendor *model.File, gomod map[string]*model.File) (*model.DepGraph, []string) {
+
+	work := ReadGomod(file)
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	// 工作区中的模块 key:模块路径
+	modNode := map[string]*model.DepGraph{}
+	var mods []*GoMod
+	var dirs []string
+	for _, use := range work.Use {
+		dir := filepath.Join(filepath.Dir(file.Relpath()), filepath.FromSlash(use))
+		f, ok := gomod[dir]
+		if !ok {
+			continue
+		}
+		mod := ReadGomod(f)
+		node := &model.DepGraph{Name: mod.Module, Path: f.Relpath()}
+		modNode[mod.Module] = node
+		mods = append(mods, mod)
+		dirs = append(dirs, dir)
+		root.AppendChild(node)
+	}
+
+	// resolve 替换后的模块 go.work中的替换优先
+	resolve := func(mod *GoMod, req *GoRequire) (string, string) {
+		r := work.replace(req.Path, req.Version)
+		if r == nil {
+			r = mod.replace(req.Path, req.Version)
+		}
+		name, version := replaced(r, req.Path, req.Version)
+		return name, goVersion(version)
+	}
+
+	// 同一模块仅保留最高版本
+	versions := map[string]string{}
+	for _, mod := range mods {
+		for _, req := range mod.Require {
+			if _, ok := modNode[req.Path]; ok {
+				continue
+			}
+			name, version := resolve(mod, req)
+			if v, ok := versions[name]; !ok || goVersionLess(v, version) {
+				versions[name] = version
+			}
+		}
+	}
+
+	// vendor中记录的版本即为实际使用的版本
+	if vendor != nil {
+		for _, m := range ParseVendorModules(vendor) {
+			name, version := replaced(m.Replace, m.Path, m.Version)
+			if _, ok := versions[name]; ok {
+				versions[name] = goVersion(version)
+			}
+		}
+	}
+
+	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
+		return &model.DepGraph{Name: s[0], Version: versions[s[0]]}
+	}).LoadOrStore
+
+	for _, mod := range mods {
+		node := modNode[mod.Module]
+		for _, req := range mod.Require {
+			if sub, ok := modNode[req.Path]; ok {
+				if sub != node {
+					node.AppendChild(sub)
+				}
+				continue
+			}
+			name, _ := resolve(mod, req)
+			node.AppendChild(_dep(name))
+		}
+	}
+
+	return root, dirs
+}
@@ -0,0 +1,183 @@
+package golang
+
+import (
+	"io"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// GoMod go.mod/go.work文件结构
+type GoMod struct {
+	File *model.File
+	// 模块路径 go.work中为空
+	Module  string
+	Require []*GoRequire
+	Replace []*GoReplace
+	Exclude []*GoRequire
+	// go.work中引用的本地模块目录
+	Use []string
+}
+
+// GoRequire 依赖的模块
+type GoRequire struct {
+	Path    string
+	Version string
+	// 是否标记了 // indirect
+	Indirect bool
+}
+
+// GoReplace 模块替换 例 old v1.0.0 => new v1.1.0 | old => ../new
+type GoReplace struct {
+	Old        string
+	OldVersion string
+	New        string
+	NewVersion string
+}
+
+// Local 是否替换为本地目录
+func (r *GoReplace) Local() bool {
+	return strings.HasPrefix(r.New, "./") || strings.HasPrefix(r.New, "../") ||
+		strings.HasPrefix(r.New, ".\\") || strings.HasPrefix(r.New, "..\\") ||
+		strings.HasPrefix(r.New, "/") || (len(r.New) > 1 && r.New[1] == ':')
+}
+
+// ReadGomod 读取go.mod/go.work文件
+func ReadGomod(file *model.File) *GoMod {
+	mod := &GoMod{}
+	file.OpenReader(func(reader io.Reader) {
+		mod = parseGomod(reader)
+	})
+	mod.File = file
+	return mod
+}
+
+// parseGomod 解析go.mod/go.work内容
+func parseGomod(reader io.Reader) *GoMod {
+
+	mod := &GoMod{}
+
+	// 当前所在的块指令 例 require (
+	var block string
+
+	model.ReadLine(reader, func(line string) {
+
+		// 注释
+		var comment string
+		if i := strings.Index(line, "//"); i != -1 {
+			comment = strings.TrimSpace(line[i+2:])
+			line = line[:i]
+		}
+
+		words := strings.Fields(line)
+		for i := range words {
+			words[i] = strings.Trim(words[i], `"`+"`")
+		}
+		if len(words) == 0 {
+			return
+		}
+
+		if block != "" {
+			if words[0] == ")" {
+				block = ""
+				return
+			}
+		} else {
+			if len(words) >= 2 && words[len(words)-1] == "(" {
+				block = words[0]
+				return
+			}
+			block, words = words[0], words[1:]
+			defer func() { block = "" }()
+		}
+
+		switch block {
+		case "module":
+			if len(words) > 0 {
+				mod.Module = words[0]
+			}
+		case "require", "exclude":
+			if len(words) < 2 {
+				return
+			}
+			req := &GoRequire{
+				Path:     words[0],
+				Version:  words[1],
+				Indirect: comment == "indirect" || strings.HasPrefix(comment, "indirect;"),
+			}
+			if block == "require" {
+				mod.Require = append(mod.Require, req)
+			} else {
+				mod.Exclude = append(mod.Exclude, req)
+			}
+		case "replace":
+			i := -1
+			for j, w := range words {
+				if w == "=>" {
+					i = j
+				}
+			}
+			if i < 1 || i+1 >= len(words) {
+				return
+			}
+			r := &GoReplace{Old: words[0], New: words[i+1]}
+			if i > 1 {
+				r.OldVersion = words[1]
+			}
+			if i+2 < len(words) {
+				r.NewVersion = words[i+2]
+			}
+			mod.Replace = append(mod.Replace, r)
+		case "use":
+			if len(words) > 0 {
+				mod.Use = append(mod.Use, words[0])
+			}
+		}
+	})
+
+	return mod
+}
+
+// replace 获取模块对应的替换 指定版本的替换优先
+func (mod *GoMod) replace(path, version string) *GoReplace {
+	if mod == nil {
+		return nil
+	}
+	var res *GoReplace
+	for _, r := range mod.Replace {
+		if r.Old != path {
+			continue
+		}
+		if r.OldVersion == version {
+			return r
+		}
+		if r.OldVersion == "" {
+			res = r
+		}
+	}
+	return res
+}
+
+// replaced 替换后的模块 替换为本地目录时保留原模块
+func replaced(r *GoReplace, path, version string) (string, string) {
+	if r == nil || r.Local() {
+		return path, version
+	}
+	return r.New, r.NewVersion
+}
+
+// goVersion 去除版本号中的+incompatible
+func goVersion(version string) string {
+	return strings.TrimSuffix(version, "+incompatible")
+}
+
+// goVersionLess 比较模块版本
+func goVersionLess(v1, v2 string) bool {
+	s1, err1 := semver.NewVersion(v1)
+	s2, err2 := semver.NewVersion(v2)
+	if err1 != nil || err2 != nil {
+		return v1 < v2
+	}
+	return s1.LessThan(s2)
+}
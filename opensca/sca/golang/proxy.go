@@ -0,0 +1,147 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/common"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/cache"
+)
+
+// defaultGoProxy 未设置GOPROXY环境变量时使用的代理 为空时不访问代理
+var defaultGoProxy []common.RepoConfig
+
+// RegisterGoProxy 注册GOPROXY
+func RegisterGoProxy(repos ...common.RepoConfig) {
+	newRepo := common.TrimRepo(repos...)
+	if len(newRepo) > 0 {
+		defaultGoProxy = newRepo
+	}
+}
+
+// goProxy 当前使用的GOPROXY GOPROXY环境变量优先
+// 支持 https://xxx | file:///xxx 不支持direct
+func goProxy() []common.RepoConfig {
+	env := os.Getenv("GOPROXY")
+	if env == "" {
+		return defaultGoProxy
+	}
+	var repos []common.RepoConfig
+	for _, url := range strings.FieldsFunc(env, func(r rune) bool { return r == ',' || r == '|' }) {
+		url = strings.TrimSpace(url)
+		if url == "off" {
+			break
+		}
+		if url == "direct" || url == "" {
+			continue
+		}
+		repos = append(repos, common.RepoConfig{Url: url})
+	}
+	return repos
+}
+
+// goNoProxy 模块是否不通过GOPROXY获取 GONOPROXY未设置时使用GOPRIVATE
+func goNoProxy(mod string) bool {
+	patterns := os.Getenv("GONOPROXY")
+	if patterns == "" {
+		patterns = os.Getenv("GOPRIVATE")
+	}
+	return matchPrefixPatterns(patterns, mod)
+}
+
+// matchPrefixPatterns 模块路径前缀是否匹配以逗号分隔的glob 同golang.org/x/mod/module.MatchPrefixPatterns
+func matchPrefixPatterns(globs, target string) bool {
+	for globs != "" {
+		var glob string
+		glob, globs, _ = strings.Cut(globs, ",")
+		glob = strings.TrimSpace(glob)
+		if glob == "" {
+			continue
+		}
+		// 取与glob层级数相同的路径前缀
+		n := strings.Count(glob, "/")
+		prefix := target
+		for i := 0; i < len(target); i++ {
+			if target[i] == '/' {
+				if n == 0 {
+					prefix = target[:i]
+					break
+				}
+				n--
+			}
+		}
+		if n > 0 {
+			continue
+		}
+		if ok, _ := path.Match(glob, prefix); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// escapePath 转义模块路径及版本 大写字母转为!+小写字母
+func escapePath(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// goModOrigin 获取模块指定版本的go.mod 无法获取时返回nil
+var goModOrigin = func(path, version string) *GoMod {
+
+	if version == "" || goNoProxy(path) {
+		return nil
+	}
+
+	repos := goProxy()
+	if len(repos) == 0 {
+		return nil
+	}
+
+	var mod *GoMod
+
+	// 读取缓存
+	cachePath := cache.Path("", escapePath(path), escapePath(version), model.Lan_Golang)
+	cache.Load(cachePath, func(reader io.Reader) {
+		mod = parseGomod(reader)
+	})
+
+	if mod != nil {
+		return mod
+	}
+
+	// 从GOPROXY下载
+	route := fmt.Sprintf("%s/@v/%s.mod", escapePath(path), escapePath(version))
+	common.DownloadUrlFromRepos(route, func(repo common.RepoConfig, r io.Reader) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			logs.Warn(err)
+			return
+		}
+		mod = parseGomod(bytes.NewReader(data))
+		cache.Save(cachePath, bytes.NewReader(data))
+	}, repos...)
+
+	return mod
+}
+
+// RegisterGoModOrigin 注册go.mod数据源
+func RegisterGoModOrigin(origin func(path, version string) *GoMod) {
+	if origin != nil {
+		goModOrigin = origin
+	}
+}
@@ -16,7 +16,12 @@ func (sca Sca) Language() model.Language {
 }
 
 func (sca Sca) Filter(relpath string) bool {
-	return filter.GoMod(relpath) || filter.GoSum(relpath) || filter.GoPkgToml(relpath) || filter.GoPkgLock(relpath)
+	return filter.GoMod(relpath) || filter.GoSum(relpath) || filter.GoPkgToml(relpath) || filter.GoPkgLock(relpath) ||
+		filter.GoWork(relpath) || filter.GoVendorModules(relpath)
+}
+
+func (sca Sca) ParseBinary(file *model.File) *model.DepGraph {
+	return ParseGoBinary(file)
 }
 
 func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
@@ -26,6 +31,9 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 	gosum := map[string]*model.File{}
 	pkglock := map[string]*model.File{}
 	pkgtoml := map[string]*model.File{}
+	gowork := map[string]*model.File{}
+	// key:vendor所在目录
+	vendor := map[string]*model.File{}
 
 	// 记录相关文件
 	for _, f := range files {
@@ -39,8 +47,32 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 		if filter.GoMod(f.Relpath()) {
 			gomod[dir] = f
 		}
+		// 同目录下go.sum优先于go.work.sum
 		if filter.GoSum(f.Relpath()) {
-			gosum[dir] = f
+			if _, ok := gosum[dir]; !ok || filepath.Base(f.Relpath()) == "go.sum" {
+				gosum[dir] = f
+			}
+		}
+		if filter.GoWork(f.Relpath()) {
+			gowork[dir] = f
+		}
+		if filter.GoVendorModules(f.Relpath()) {
+			vendor[filepath.Dir(dir)] = f
+		}
+	}
+
+	// 解析go.work 工作区中的模块不再单独解析
+	for dir, f := range gowork {
+		work, dirs := ParseGowork(f, vendor[dir], gomod)
+		call(f, work)
+		delete(vendor, dir)
+		if sum, ok := gosum[dir]; ok && filepath.Base(sum.Relpath()) == "go.work.sum" {
+			delete(gosum, dir)
+		}
+		for _, d := range dirs {
+			delete(gomod, d)
+			delete(gosum, d)
+			delete(vendor, d)
 		}
 	}
 
@@ -52,21 +84,31 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 				call(f, graph)
 				delete(gomod, dir)
 				delete(gosum, dir)
+				delete(vendor, dir)
 			}
 		}
 	}
 
-	// 静态解析go.sum
-	for dir, f := range gosum {
-		sum := ParseGosum(f)
-		call(f, sum)
+	// 静态解析vendor/modules.txt
+	for dir, f := range vendor {
+		call(f, ParseGomodWithVendor(gomod[dir], f))
 		delete(gomod, dir)
+		delete(gosum, dir)
 	}
 
-	// 静态解析go.mod
-	for _, f := range gomod {
-		mod := ParseGomod(f)
-		call(f, mod)
+	// 静态解析go.mod 以go.sum补全间接依赖
+	for dir, f := range gomod {
+		if sum, ok := gosum[dir]; ok {
+			call(f, ParseGomodWithGosum(f, sum))
+			delete(gosum, dir)
+		} else {
+			call(f, ParseGomod(f))
+		}
+	}
+
+	// 静态解析go.sum
+	for _, f := range gosum {
+		call(f, ParseGosum(f))
 	}
 
 	// 静态解析gopkg.lock
@@ -0,0 +1,95 @@
+package golang
+
+import (
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// VendorModule vendor/modules.txt中记录的模块
+type VendorModule struct {
+	Path    string
+	Version string
+	// 替换后的模块
+	Replace *GoReplace
+	// 是否在go.mod中显式依赖
+	Explicit bool
+}
+
+// ParseVendorModules 解析vendor/modules.txt
+// # github.com/pkg/errors v0.9.1
+// ## explicit; go 1.12
+// github.com/pkg/errors
+// # golang.org/x/text v0.3.0 => golang.org/x/text v0.3.2
+func ParseVendorModules(file *model.File) []*VendorModule {
+
+	var mods []*VendorModule
+	var cur *VendorModule
+
+	file.ReadLine(func(line string) {
+
+		line = strings.TrimSpace(line)
+
+		if s, ok := strings.CutPrefix(line, "## "); ok {
+			if cur == nil {
+				return
+			}
+			for _, attr := range strings.Split(s, ";") {
+				if strings.TrimSpace(attr) == "explicit" {
+					cur.Explicit = true
+				}
+			}
+			return
+		}
+
+		s, ok := strings.CutPrefix(line, "# ")
+		if !ok {
+			return
+		}
+
+		cur = nil
+		old, repl, _ := strings.Cut(s, "=>")
+		words := strings.Fields(old)
+		// 仅记录替换关系的行 例 # example.com/a => ./a
+		if len(words) < 2 {
+			return
+		}
+
+		cur = &VendorModule{Path: words[0], Version: words[1]}
+		if words = strings.Fields(repl); len(words) > 0 {
+			cur.Replace = &GoReplace{Old: cur.Path, OldVersion: cur.Version, New: words[0]}
+			if len(words) > 1 {
+				cur.Replace.NewVersion = words[1]
+			}
+		}
+		mods = append(mods, cur)
+	})
+
+	return mods
+}
+
+// ParseGomodWithVendor 以vendor/modules.txt中的模块版本解析go.mod
+// modfile: go.mod 不存在时为nil
+func ParseGomodWithVendor(modfile, vendor *model.File) *model.DepGraph {
+
+	mods := ParseVendorModules(vendor)
+	if modfile != nil {
+		return parseGomodGraph(modfile, mods)
+	}
+
+	root := &model.DepGraph{Path: vendor.Relpath()}
+
+	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
+		return &model.DepGraph{Name: s[0], Version: s[1]}
+	}).LoadOrStore
+
+	// 没有go.mod时无法确定模块间的依赖关系 均作为直接依赖 未显式依赖的模块标记为间接依赖
+	for _, m := range mods {
+		name, version := replaced(m.Replace, m.Path, m.Version)
+		dep := _dep(name, goVersion(version))
+		dep.Indirect = !m.Explicit
+		root.AppendChild(dep)
+	}
+
+	return root
+}
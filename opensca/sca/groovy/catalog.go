@@ -0,0 +1,116 @@
+package groovy
+
+import (
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+// VersionCatalog gradle版本目录 例 gradle/libs.versions.toml
+type VersionCatalog struct {
+	// key:版本名 value:字符串或富版本 例 { strictly = "[1.0,2.0[", prefer = "1.5" }
+	Versions map[string]any `toml:"versions"`
+	// key:别名 value:字符串或表 例 "g:a:v" | { module = "g:a", version.ref = "x" }
+	Libraries map[string]any `toml:"libraries"`
+	// key:别名 value:库别名列表
+	Bundles map[string][]string `toml:"bundles"`
+}
+
+// ReadVersionCatalog 读取版本目录
+func ReadVersionCatalog(file *model.File) *VersionCatalog {
+	catalog := &VersionCatalog{}
+	file.OpenReader(func(reader io.Reader) {
+		if _, err := toml.NewDecoder(reader).Decode(catalog); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+	return catalog
+}
+
+// accessor 别名对应的访问路径 -和_均转为. 例 commons-lang3=>commons.lang3
+func accessor(alias string) string {
+	return strings.NewReplacer("-", ".", "_", ".").Replace(alias)
+}
+
+// version 解析版本 富版本优先使用prefer>require>strictly
+func (c *VersionCatalog) version(v any) string {
+	switch ver := v.(type) {
+	case string:
+		return ver
+	case map[string]any:
+		if ref, ok := ver["ref"].(string); ok {
+			// [versions]中的版本不再引用其他版本
+			if rv, ok := c.Versions[ref].(map[string]any); ok {
+				if _, ok := rv["ref"]; ok {
+					return ""
+				}
+			}
+			return c.version(c.Versions[ref])
+		}
+		for _, k := range []string{"prefer", "require", "strictly"} {
+			if s, ok := ver[k].(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// library 解析库坐标 版本由平台管理时版本号为空
+func (c *VersionCatalog) library(v any) *java.PomDependency {
+	switch lib := v.(type) {
+	case string:
+		// g:a:v | g:a
+		parts := strings.Split(lib, ":")
+		if len(parts) < 2 {
+			return nil
+		}
+		dep := &java.PomDependency{GroupId: parts[0], ArtifactId: parts[1]}
+		if len(parts) > 2 {
+			dep.Version = parts[2]
+		}
+		return dep
+	case map[string]any:
+		dep := &java.PomDependency{}
+		if module, ok := lib["module"].(string); ok {
+			dep.GroupId, dep.ArtifactId, _ = strings.Cut(module, ":")
+		} else {
+			dep.GroupId, _ = lib["group"].(string)
+			dep.ArtifactId, _ = lib["name"].(string)
+		}
+		dep.Version = c.version(lib["version"])
+		if dep.GroupId == "" || dep.ArtifactId == "" {
+			return nil
+		}
+		return dep
+	}
+	return nil
+}
+
+// Lookup 查找访问路径对应的库 例 guava | commons.lang3 | bundles.testing
+func (c *VersionCatalog) Lookup(path string) []*java.PomDependency {
+	var deps []*java.PomDependency
+	if name, ok := strings.CutPrefix(path, "bundles."); ok {
+		for alias, libs := range c.Bundles {
+			if accessor(alias) != name {
+				continue
+			}
+			for _, lib := range libs {
+				deps = append(deps, c.Lookup(accessor(lib))...)
+			}
+		}
+		return deps
+	}
+	for alias, lib := range c.Libraries {
+		if accessor(alias) == path {
+			if dep := c.library(lib); dep != nil {
+				deps = append(deps, dep)
+			}
+		}
+	}
+	return deps
+}
@@ -0,0 +1,622 @@
+package groovy
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	// 单引号字符串 不支持插值
+	tokenString
+	// 双引号字符串 可能包含插值 例 "g:a:$version"
+	tokenGString
+	tokenNumber
+	tokenPunct
+	// 换行或分号 语句结束
+	tokenNewline
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize 将Groovy/Kotlin DSL脚本拆分为词法单元 忽略注释
+func tokenize(text string) []token {
+
+	s := []rune(text)
+	var tokens []token
+	add := func(kind tokenKind, text string) {
+		// 合并连续的换行
+		if kind == tokenNewline && (len(tokens) == 0 || tokens[len(tokens)-1].kind == tokenNewline) {
+			return
+		}
+		tokens = append(tokens, token{kind, text})
+	}
+
+	hasPrefix := func(i int, prefix string) bool {
+		return strings.HasPrefix(string(s[i:min(i+len(prefix), len(s))]), prefix)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\n' || c == ';':
+			add(tokenNewline, "\n")
+			i++
+		case unicode.IsSpace(c):
+			i++
+		case hasPrefix(i, "//"):
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case hasPrefix(i, "/*"):
+			i += 2
+			for i < len(s) && !hasPrefix(i, "*/") {
+				i++
+			}
+			i += 2
+		case c == '"' || c == '\'':
+			// 例 'x' | "x" | '''x''' | """x"""
+			quote := string(c)
+			if hasPrefix(i, strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+			}
+			i += len(quote)
+			var sb strings.Builder
+			// 插值表达式中可能包含引号 例 "${extra["version"]}"
+			depth := 0
+			for i < len(s) && (depth > 0 || !hasPrefix(i, quote)) {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				} else if c == '"' && hasPrefix(i, "${") {
+					depth++
+				} else if depth > 0 && s[i] == '}' {
+					depth--
+				}
+				sb.WriteRune(s[i])
+				i++
+			}
+			i += len(quote)
+			kind := tokenString
+			if c == '"' {
+				kind = tokenGString
+			}
+			add(kind, sb.String())
+		case c == '_' || c == '$' || unicode.IsLetter(c):
+			start := i
+			for i < len(s) && (s[i] == '_' || s[i] == '$' || unicode.IsLetter(s[i]) || unicode.IsDigit(s[i])) {
+				i++
+			}
+			add(tokenIdent, string(s[start:i]))
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(s) && (s[i] == '.' || s[i] == '_' || unicode.IsLetter(s[i]) || unicode.IsDigit(s[i])) {
+				i++
+			}
+			add(tokenNumber, string(s[start:i]))
+		default:
+			add(tokenPunct, string(c))
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// GradleScript 构建脚本中声明的依赖
+type GradleScript struct {
+	Dependencies []*java.PomDependency
+	// platform引入的bom及constraints中的版本约束 作为dependencyManagement
+	Management []*java.PomDependency
+	// configurations中全局排除的依赖
+	Exclusions []*java.PomDependency
+}
+
+// configurationScope 依赖配置对应的maven scope
+// test相关配置、注解处理器、buildscript及debug构建中的依赖为开发依赖 compileOnly为provided
+func configurationScope(configuration string) string {
+	c := strings.ToLower(configuration)
+	switch {
+	case strings.Contains(c, "test"),
+		strings.HasPrefix(c, "debug"),
+		strings.HasPrefix(c, "kapt"),
+		strings.HasPrefix(c, "ksp"),
+		strings.HasSuffix(c, "annotationprocessor"),
+		c == "classpath",
+		c == "lintchecks",
+		c == "detektplugins",
+		c == "developmentonly":
+		return "test"
+	case c == "compileonly" || c == "compileonlyapi":
+		return "provided"
+	}
+	return ""
+}
+
+// parseCoordinate 解析依赖坐标 例 g:a:v | g:a:v:classifier@ext | g:a
+func parseCoordinate(coord string) *java.PomDependency {
+	if i := strings.Index(coord, "@"); i != -1 {
+		coord = coord[:i]
+	}
+	parts := strings.Split(coord, ":")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+	dep := &java.PomDependency{GroupId: parts[0], ArtifactId: parts[1]}
+	if len(parts) > 2 {
+		// 1.0!! 为 strictly 1.0 的简写
+		dep.Version = strings.TrimSuffix(parts[2], "!!")
+	}
+	if len(parts) > 3 {
+		dep.Classifier = parts[3]
+	}
+	return dep
+}
+
+// dslParser 解析构建脚本中的依赖声明
+type dslParser struct {
+	tokens   []token
+	i        int
+	v        Variable
+	catalogs map[string]*VersionCatalog
+	script   *GradleScript
+}
+
+// ReadGradleScript 读取build.gradle或build.gradle.kts中的依赖
+// v: 构建中的变量表
+// catalogs: key:版本目录访问名 例 libs
+func ReadGradleScript(file *model.File, v Variable, catalogs map[string]*VersionCatalog) *GradleScript {
+	var text string
+	file.OpenReader(func(reader io.Reader) {
+		data, _ := io.ReadAll(reader)
+		text = string(data)
+	})
+	p := &dslParser{tokens: tokenize(text), v: v, catalogs: catalogs, script: &GradleScript{}}
+	p.walk(nil)
+	return p.script
+}
+
+func (p *dslParser) eof() bool {
+	return p.i >= len(p.tokens)
+}
+
+func (p *dslParser) peek(n int) token {
+	if p.i+n < len(p.tokens) {
+		return p.tokens[p.i+n]
+	}
+	return token{kind: tokenNewline}
+}
+
+func (p *dslParser) is(n int, kind tokenKind, text string) bool {
+	t := p.peek(n)
+	return p.i+n < len(p.tokens) && t.kind == kind && t.text == text
+}
+
+// blockName 代码块的名称 例 dependencies { | configurations.all { | project(':a') {
+func (p *dslParser) blockName() string {
+	j := p.i - 1
+	// 跳过参数
+	if j >= 0 && p.tokens[j].kind == tokenPunct && p.tokens[j].text == ")" {
+		depth := 0
+		for ; j >= 0; j-- {
+			if p.tokens[j].kind == tokenPunct && p.tokens[j].text == ")" {
+				depth++
+			}
+			if p.tokens[j].kind == tokenPunct && p.tokens[j].text == "(" {
+				depth--
+				if depth == 0 {
+					j--
+					break
+				}
+			}
+		}
+	}
+	var names []string
+	for ; j >= 0 && p.tokens[j].kind == tokenIdent; j -= 2 {
+		names = append([]string{p.tokens[j].text}, names...)
+		if j == 0 || p.tokens[j-1].kind != tokenPunct || p.tokens[j-1].text != "." {
+			break
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// walk 遍历代码块 stack: 当前所在的代码块名称
+func (p *dslParser) walk(stack []string) {
+	for !p.eof() {
+		t := p.peek(0)
+		switch {
+		case t.kind == tokenPunct && t.text == "{":
+			name := p.blockName()
+			p.i++
+			if name == "dependencies" {
+				p.dependencies(false)
+			} else {
+				p.walk(append(stack, name))
+			}
+		case t.kind == tokenPunct && t.text == "}":
+			p.i++
+			if len(stack) > 0 {
+				return
+			}
+		case t.kind == tokenIdent && t.text == "exclude" && inConfigurations(stack):
+			// configurations.all { exclude group: 'g', module: 'm' }
+			p.i++
+			if ex := p.exclusion(p.args()); ex != nil {
+				p.script.Exclusions = append(p.script.Exclusions, ex)
+			}
+		default:
+			p.i++
+		}
+	}
+}
+
+func inConfigurations(stack []string) bool {
+	for _, name := range stack {
+		if strings.HasPrefix(name, "configurations") {
+			return true
+		}
+	}
+	return false
+}
+
+// args 读取调用参数 支持 f(a, b) 及 f a, b 两种形式 返回以逗号分隔的参数列表
+func (p *dslParser) args() [][]token {
+
+	var args [][]token
+	var arg []token
+	paren := p.is(0, tokenPunct, "(")
+	if paren {
+		p.i++
+	}
+
+	depth := 0
+	for !p.eof() {
+		t := p.peek(0)
+		if t.kind == tokenPunct {
+			switch t.text {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				if depth == 0 && paren {
+					p.i++
+					return append(args, arg)
+				}
+				depth--
+			case "{", "}":
+				// 命令形式参数在闭包或代码块结束前终止
+				if depth == 0 && !paren {
+					return append(args, arg)
+				}
+			case ",":
+				if depth == 0 {
+					args = append(args, arg)
+					arg = nil
+					p.i++
+					// 逗号后换行的参数延续到下一行
+					if p.peek(0).kind == tokenNewline {
+						p.i++
+					}
+					continue
+				}
+			}
+		}
+		if t.kind == tokenNewline {
+			if depth == 0 && !paren {
+				return append(args, arg)
+			}
+			p.i++
+			continue
+		}
+		arg = append(arg, t)
+		p.i++
+	}
+	return append(args, arg)
+}
+
+// str 计算字符串表达式 支持插值、变量及+拼接 无法解析时包含$
+func (p *dslParser) str(tokens []token) string {
+	var sb strings.Builder
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.kind {
+		case tokenString, tokenNumber:
+			sb.WriteString(t.text)
+		case tokenGString:
+			sb.WriteString(p.interpolate(t.text))
+		case tokenIdent:
+			// 变量引用 例 springVersion | versions.spring | extra["spring"]
+			chain := t.text
+			for {
+				if i+2 < len(tokens) && tokens[i+1].kind == tokenPunct && tokens[i+1].text == "." && tokens[i+2].kind == tokenIdent {
+					chain += "." + tokens[i+2].text
+					i += 2
+					continue
+				}
+				if i+3 < len(tokens) && tokens[i+1].kind == tokenPunct && tokens[i+1].text == "[" && tokens[i+3].kind == tokenPunct && tokens[i+3].text == "]" {
+					chain += "." + tokens[i+2].text
+					i += 3
+					continue
+				}
+				break
+			}
+			if value, ok := p.v[chain]; ok {
+				sb.WriteString(value)
+			} else {
+				sb.WriteString("$" + chain)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// interpolateReg 字符串中的插值表达式 例 ${extra["version"]}
+var interpolateReg = regexp.MustCompile(`\$\{([^{}]*)\}`)
+
+// interpolate 替换字符串中的插值
+func (p *dslParser) interpolate(text string) string {
+	text = interpolateReg.ReplaceAllStringFunc(text, func(s string) string {
+		return p.str(tokenize(s[2 : len(s)-1]))
+	})
+	return p.v.Replace(text)
+}
+
+// namedArgs 解析命名参数 例 group: 'g', name: 'a' | group = "g", module = "a"
+func (p *dslParser) namedArgs(args [][]token) map[string]string {
+	named := map[string]string{}
+	for _, arg := range args {
+		if len(arg) >= 3 && arg[0].kind == tokenIdent && arg[1].kind == tokenPunct && (arg[1].text == ":" || arg[1].text == "=") {
+			named[arg[0].text] = p.str(arg[2:])
+		}
+	}
+	return named
+}
+
+// exclusion 解析排除规则 例 exclude group: 'g', module: 'm'
+func (p *dslParser) exclusion(args [][]token) *java.PomDependency {
+	named := p.namedArgs(args)
+	ex := &java.PomDependency{GroupId: named["group"], ArtifactId: named["module"]}
+	if ex.GroupId == "" && ex.ArtifactId == "" {
+		return nil
+	}
+	return ex
+}
+
+// resolve 解析单个参数表达式中的依赖
+// platform: 依赖是否通过platform/enforcedPlatform引入
+func (p *dslParser) resolve(arg []token) (deps []*java.PomDependency, platform bool) {
+
+	if len(arg) == 0 {
+		return
+	}
+
+	// 函数调用 例 platform("g:a:v") | kotlin("stdlib", "1.9.0") | project(":a")
+	if arg[0].kind == tokenIdent && len(arg) > 1 && arg[1].kind == tokenPunct && arg[1].text == "(" && arg[len(arg)-1].text == ")" {
+		inner := &dslParser{tokens: arg[1:], v: p.v, catalogs: p.catalogs, script: p.script}
+		args := inner.args()
+		switch arg[0].text {
+		case "platform", "enforcedPlatform":
+			for _, a := range args {
+				ds, _ := p.resolve(a)
+				deps = append(deps, ds...)
+			}
+			return deps, true
+		case "testFixtures":
+			for _, a := range args {
+				ds, _ := p.resolve(a)
+				deps = append(deps, ds...)
+			}
+			return deps, false
+		case "kotlin":
+			dep := &java.PomDependency{GroupId: "org.jetbrains.kotlin", ArtifactId: "kotlin-" + p.str(args[0])}
+			if len(args) > 1 {
+				dep.Version = p.str(args[1])
+			}
+			return []*java.PomDependency{dep}, false
+		}
+		return
+	}
+
+	// 版本目录或变量引用 例 libs.commons.lang3 | libs.bundles.testing | deps.guava
+	if arg[0].kind == tokenIdent {
+		var chain []string
+		for i := 0; i < len(arg); i += 2 {
+			if arg[i].kind != tokenIdent {
+				break
+			}
+			chain = append(chain, arg[i].text)
+			if i+1 >= len(arg) || arg[i+1].text != "." {
+				break
+			}
+		}
+		if catalog, ok := p.catalogs[chain[0]]; ok && len(chain) > 1 {
+			path := strings.TrimSuffix(strings.Join(chain[1:], "."), ".get")
+			// 版本及插件引用不是依赖
+			if strings.HasPrefix(path, "versions.") || strings.HasPrefix(path, "plugins.") {
+				return
+			}
+			return catalog.Lookup(path), false
+		}
+	}
+
+	// 字符串坐标 例 "g:a:$version"
+	if dep := parseCoordinate(p.str(arg)); dep != nil {
+		deps = append(deps, dep)
+	}
+	return
+}
+
+// closure 解析依赖后的闭包 例 { exclude group: 'g'; version { strictly '1.0' }; transitive = false }
+func (p *dslParser) closure(deps []*java.PomDependency) {
+	p.i++
+	depth := 0
+	versions := map[string]string{}
+	for !p.eof() {
+		t := p.peek(0)
+		switch {
+		case t.kind == tokenPunct && t.text == "{":
+			depth++
+		case t.kind == tokenPunct && t.text == "}":
+			if depth == 0 {
+				p.i++
+				// 版本优先级 prefer>require>strictly
+				for _, k := range []string{"strictly", "require", "prefer"} {
+					if v := versions[k]; v != "" {
+						for _, dep := range deps {
+							dep.Version = v
+						}
+					}
+				}
+				return
+			}
+			depth--
+		case t.kind == tokenIdent && t.text == "exclude":
+			p.i++
+			if ex := p.exclusion(p.args()); ex != nil {
+				for _, dep := range deps {
+					dep.Exclusions = append(dep.Exclusions, ex)
+				}
+			}
+			continue
+		case t.kind == tokenIdent && (t.text == "strictly" || t.text == "require" || t.text == "prefer"):
+			p.i++
+			if args := p.args(); len(args) > 0 {
+				versions[t.text] = p.str(args[0])
+			}
+			continue
+		case t.kind == tokenIdent && (t.text == "transitive" || t.text == "isTransitive"):
+			// transitive = false 不引入间接依赖
+			if p.is(1, tokenPunct, "=") && p.is(2, tokenIdent, "false") {
+				for _, dep := range deps {
+					dep.Exclusions = append(dep.Exclusions, &java.PomDependency{GroupId: "*", ArtifactId: "*"})
+				}
+			}
+		}
+		p.i++
+	}
+}
+
+// keywords dependencies代码块中不是依赖配置的关键字
+var keywords = map[string]bool{
+	"if": true, "else": true, "for": true, "while": true, "when": true, "switch": true,
+	"def": true, "val": true, "var": true, "return": true,
+}
+
+// dependencies 解析dependencies代码块 constraints: 是否为constraints代码块
+func (p *dslParser) dependencies(constraints bool) {
+
+	for !p.eof() {
+
+		t := p.peek(0)
+
+		if t.kind == tokenNewline {
+			p.i++
+			continue
+		}
+
+		if t.kind == tokenPunct {
+			p.i++
+			switch t.text {
+			case "}":
+				return
+			case "{":
+				// 条件语句等嵌套代码块
+				p.dependencies(constraints)
+			}
+			continue
+		}
+
+		// constraints { ... }
+		if t.kind == tokenIdent && t.text == "constraints" && p.is(1, tokenPunct, "{") {
+			p.i += 2
+			p.dependencies(true)
+			continue
+		}
+
+		var configuration string
+		var args [][]token
+		switch {
+		case t.kind == tokenIdent && keywords[t.text]:
+			// 条件及循环语句 后续代码块按嵌套代码块解析
+			p.i++
+			continue
+		case t.kind == tokenIdent && t.text == "add" && p.is(1, tokenPunct, "("):
+			// add("implementation", "g:a:v")
+			p.i++
+			args = p.args()
+			if len(args) > 0 {
+				configuration = p.str(args[0])
+				args = args[1:]
+			}
+		case (t.kind == tokenString || t.kind == tokenGString) && p.is(1, tokenPunct, "("):
+			// "implementation"("g:a:v")
+			p.i++
+			configuration = t.text
+			args = p.args()
+		case t.kind == tokenIdent && (p.is(1, tokenPunct, "(") || p.peek(1).kind == tokenString || p.peek(1).kind == tokenGString || p.peek(1).kind == tokenIdent):
+			// implementation("g:a:v") | implementation 'g:a:v' | implementation libs.guava
+			p.i++
+			configuration = t.text
+			args = p.args()
+		default:
+			p.i++
+			continue
+		}
+
+		var deps, platforms []*java.PomDependency
+
+		// 命名参数 例 group: 'g', name: 'a', version: 'v'
+		if named := p.namedArgs(args); named["group"] != "" && (named["name"] != "" || named["module"] != "") {
+			dep := &java.PomDependency{GroupId: named["group"], ArtifactId: named["name"], Version: named["version"], Classifier: named["classifier"]}
+			if dep.ArtifactId == "" {
+				dep.ArtifactId = named["module"]
+			}
+			deps = append(deps, dep)
+		} else {
+			for _, arg := range args {
+				ds, platform := p.resolve(arg)
+				if platform {
+					platforms = append(platforms, ds...)
+				} else {
+					deps = append(deps, ds...)
+				}
+			}
+		}
+
+		// 依赖后的闭包
+		if p.is(0, tokenPunct, "{") {
+			p.closure(append(deps, platforms...))
+		}
+
+		scope := configurationScope(configuration)
+		for _, dep := range deps {
+			// 无法解析的版本号留空 尝试使用dependencyManagement补全
+			if strings.Contains(dep.Version, "$") {
+				dep.Version = ""
+			}
+			if constraints {
+				p.script.Management = append(p.script.Management, dep)
+			} else {
+				dep.Scope = scope
+				p.script.Dependencies = append(p.script.Dependencies, dep)
+			}
+		}
+		for _, dep := range platforms {
+			if strings.Contains(dep.Version, "$") {
+				continue
+			}
+			dep.Type = "pom"
+			dep.Scope = "import"
+			p.script.Management = append(p.script.Management, dep)
+		}
+	}
+}
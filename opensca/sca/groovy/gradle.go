@@ -8,7 +8,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/cmd/config"
@@ -19,88 +18,105 @@ import (
 )
 
 // ParseGradle 解析gradle脚本
+// 存在gradle.lockfile时使用锁文件中的版本 否则借助pom解析间接依赖
 func ParseGradle(ctx context.Context, files []*model.File) []*model.DepGraph {
 
 	v := Variable{}
 	gradle := []*model.File{}
+	// key:构建根目录 value:版本目录 key:访问名 例 libs
+	catalogs := map[string]map[string]*VersionCatalog{}
+	// key:锁文件所在目录
+	lockfiles := map[string]*model.File{}
+	buildscripts := map[string]*model.File{}
 	for _, f := range files {
-		if filter.GroovyGradle(f.Relpath()) {
+		switch {
+		case filter.GroovyGradle(f.Relpath()):
 			v.Scan(f)
 			gradle = append(gradle, f)
+		case filter.GroovyVersionCatalog(f.Relpath()):
+			// gradle/libs.versions.toml 访问名为文件名前缀
+			dir := filepath.Dir(filepath.Dir(f.Relpath()))
+			if catalogs[dir] == nil {
+				catalogs[dir] = map[string]*VersionCatalog{}
+			}
+			catalogs[dir][strings.TrimSuffix(filepath.Base(f.Relpath()), ".versions.toml")] = ReadVersionCatalog(f)
+		case filter.GroovyGradleLockfile(f.Relpath()):
+			dir := filepath.Dir(f.Relpath())
+			if strings.HasSuffix(f.Relpath(), "buildscript-gradle.lockfile") {
+				buildscripts[dir] = f
+			} else {
+				lockfiles[dir] = f
+			}
 		}
 	}
 
-	var roots []*model.DepGraph
-
-	for _, f := range gradle {
-
-		_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
-			return &model.DepGraph{
-				Vendor:  s[0],
-				Name:    s[1],
-				Version: s[2],
-				Develop: s[3] == "dev",
+	// nearestCatalogs 构建脚本所在目录或最近的上级目录中的版本目录
+	nearestCatalogs := func(dir string) map[string]*VersionCatalog {
+		for {
+			if c, ok := catalogs[dir]; ok {
+				return c
 			}
-		}).LoadOrStore
-
-		root := &model.DepGraph{Path: f.Relpath()}
-
-		f.ReadLineNoComment(model.CTypeComment, func(line string) {
-
-			line = v.Replace(line)
-
-			for _, re := range regexs {
-				match := re.FindStringSubmatch(line)
-				if len(match) < 4 {
-					continue
-				}
+			up := filepath.Dir(dir)
+			if up == dir {
+				return nil
+			}
+			dir = up
+		}
+	}
 
-				vendor := match[1]
-				name := match[2]
-				version := match[3]
-				index := strings.Index(version, "@")
-				if index != -1 {
-					version = version[:index]
-				}
+	var roots []*model.DepGraph
 
-				if version == "" || strings.Contains(version, "$") {
-					continue
-				}
+	for _, f := range gradle {
 
-				dev := ""
-				if strings.Contains(strings.ToLower(line), "testimplementation") {
-					dev = "dev"
-				}
+		script := ReadGradleScript(f, v, nearestCatalogs(filepath.Dir(f.Relpath())))
 
-				root.AppendChild(_dep(vendor, name, version, dev))
+		// 优先使用锁文件
+		dir := filepath.Dir(f.Relpath())
+		if lockfiles[dir] != nil || buildscripts[dir] != nil {
+			declared := &model.DepGraph{Path: f.Relpath()}
+			for _, dep := range script.Dependencies {
+				declared.AppendChild(&model.DepGraph{Vendor: dep.GroupId, Name: dep.ArtifactId, Version: dep.Version})
 			}
+			roots = append(roots, ParseGradleLockfile(lockfiles[dir], buildscripts[dir], declared))
+			continue
+		}
 
-		})
-
-		roots = append(roots, root)
-	}
-
-	// 借助java模块解析间接依赖
-	for i, root := range roots {
-		virPom := &java.Pom{File: model.NewFile(root.Path, root.Path)}
-		for _, dep := range root.Children {
-			virPom.Dependencies = append(virPom.Dependencies, &java.PomDependency{GroupId: dep.Vendor, ArtifactId: dep.Name, Version: dep.Version})
+		// 借助java模块解析间接依赖 platform及constraints作为dependencyManagement
+		pom := &java.Pom{
+			File:                 model.NewFile(f.Relpath(), f.Relpath()),
+			Dependencies:         mergeDependencies(script.Dependencies),
+			DependencyManagement: script.Management,
 		}
-		java.ParsePoms(ctx, []*java.Pom{virPom}, nil, func(pom *java.Pom, pomResult *model.DepGraph) {
-			roots[i] = pomResult
+		pom.Exclusions = script.Exclusions
+		root := &model.DepGraph{Path: f.Relpath()}
+		java.ParsePoms(ctx, []*java.Pom{pom}, nil, func(pom *java.Pom, pomResult *model.DepGraph) {
+			root = pomResult
 		})
+		roots = append(roots, root)
 	}
 
 	return roots
 }
 
-// TODO: 优化gradle解析
-// 依赖冲突 https://docs.gradle.org/current/userguide/dependency_management.html
-// 依赖定义 https://docs.gradle.org/current/userguide/dependency_downgrade_and_exclude.html#sec:enforcing_dependency_version
-var regexs = []*regexp.Regexp{
-	regexp.MustCompile(`group: ?['"]([a-zA-Z]+[^\s"']+)['"], ?name: ?['"]([a-zA-Z]+[^\s"']+)['"], ?version: ?['"]([^\s"']+)['"]`),
-	regexp.MustCompile(`group: ?['"]([a-zA-Z]+[^\s"']+)['"], ?module: ?['"]([a-zA-Z]+[^\s"']+)['"], ?version: ?['"]([^\s"']+)['"]`),
-	regexp.MustCompile(`['"]([a-zA-Z]+[^\s:'"]+):([a-zA-Z]+[^\s:'"]+):([^\s:'"]+)['"]`),
+// mergeDependencies 合并重复声明的依赖 同时存在于开发及非开发配置中的依赖为非开发依赖
+func mergeDependencies(deps []*java.PomDependency) []*java.PomDependency {
+	var merged []*java.PomDependency
+	index := map[string]*java.PomDependency{}
+	for _, dep := range deps {
+		exist, ok := index[dep.Index2()]
+		if !ok {
+			index[dep.Index2()] = dep
+			merged = append(merged, dep)
+			continue
+		}
+		if exist.Version == "" {
+			exist.Version = dep.Version
+		}
+		if dep.Scope == "" {
+			exist.Scope = ""
+		}
+	}
+	return merged
 }
 
 //go:embed opensca.gradle
@@ -0,0 +1,72 @@
+package groovy
+
+import (
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// ParseGradleLockfile 解析gradle.lockfile及buildscript-gradle.lockfile 文件中没有依赖关系 间接依赖同样挂在根节点下
+// 仅在test相关配置中使用的依赖及buildscript中的依赖为开发依赖
+// lockfile/buildscript: 不存在时为nil
+// declared: 构建脚本中声明的依赖 为nil时不区分直接依赖
+func ParseGradleLockfile(lockfile, buildscript *model.File, declared *model.DepGraph) *model.DepGraph {
+
+	root := &model.DepGraph{}
+	if declared != nil {
+		root.Vendor = declared.Vendor
+		root.Name = declared.Name
+		root.Version = declared.Version
+	}
+
+	direct := map[string]bool{}
+	if declared != nil {
+		for _, dep := range declared.Children {
+			direct[dep.Vendor+":"+dep.Name] = true
+		}
+	}
+
+	// key:group:artifact:version
+	nodes := map[string]*model.DepGraph{}
+
+	parse := func(file *model.File, build bool) {
+		if file == nil {
+			return
+		}
+		if root.Path == "" {
+			root.Path = file.Relpath()
+		}
+		// 例 com.google.guava:guava:32.1.2-jre=compileClasspath,runtimeClasspath
+		file.ReadLineNoComment(&model.CommentType{Simple: "#"}, func(line string) {
+			coord, configurations, ok := strings.Cut(strings.TrimSpace(line), "=")
+			if !ok || coord == "empty" {
+				return
+			}
+			parts := strings.Split(coord, ":")
+			if len(parts) != 3 {
+				return
+			}
+			develop := true
+			if !build {
+				for _, c := range strings.Split(configurations, ",") {
+					if !strings.Contains(strings.ToLower(c), "test") {
+						develop = false
+					}
+				}
+			}
+			if dep, ok := nodes[coord]; ok {
+				dep.Develop = dep.Develop && develop
+				return
+			}
+			dep := &model.DepGraph{Vendor: parts[0], Name: parts[1], Version: parts[2], Develop: develop}
+			dep.Indirect = declared != nil && !direct[parts[0]+":"+parts[1]]
+			nodes[coord] = dep
+			root.AppendChild(dep)
+		})
+	}
+
+	parse(lockfile, false)
+	parse(buildscript, true)
+
+	return root
+}
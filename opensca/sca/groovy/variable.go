@@ -56,6 +56,15 @@ func (v Variable) Replace(text string) string {
 var startReg = regexp.MustCompile(`\s*(\w+)\s*=?\s*[\[\{]`)
 var varReg = regexp.MustCompile(`([\w]+)\s*[=:][\s\n]*['"]?([^\s()/'"]+)['"]?`)
 
+// kotlin及groovy中的变量声明
+// 例 val springVersion = "6.0.0" | def springVersion = '6.0.0' | extra["springVersion"] = "6.0.0" | set("springVersion", "6.0.0") | val springVersion by extra("6.0.0")
+var declareRegs = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^\s*(?:def|val|var|String)\s+(\w+)\s*(?::\s*String\s*)?=\s*['"]([^\s'"$]+)['"]`),
+	regexp.MustCompile(`\b(?:extra|ext)\[\s*['"](\w+)['"]\s*\]\s*=\s*['"]([^\s'"$]+)['"]`),
+	regexp.MustCompile(`\bset\(\s*['"](\w+)['"]\s*,\s*['"]([^\s'"$]+)['"]\s*\)`),
+	regexp.MustCompile(`\b(?:val|var)\s+(\w+)\s+by\s+extra\(\s*['"]([^\s'"$]+)['"]\s*\)`),
+}
+
 // Scan 提取文件中的变量
 func (v Variable) Scan(file *model.File) {
 
@@ -69,6 +78,15 @@ func (v Variable) Scan(file *model.File) {
 		text = string(data)
 	})
 
+	for _, re := range declareRegs {
+		for _, m := range re.FindAllStringSubmatch(text, -1) {
+			v[m[1]] = m[2]
+			// extra中的属性同样可以通过 ext.x 及 extra["x"] 访问
+			v["ext."+m[1]] = m[2]
+			v["extra."+m[1]] = m[2]
+		}
+	}
+
 	blockIndex := startReg.FindAllStringIndex(text, -1)
 	for i, bi := range blockIndex {
 		end := len(text)
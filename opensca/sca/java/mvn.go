@@ -32,6 +32,8 @@ func ParsePoms(ctx context.Context, poms []*Pom, exclusion []*Pom, call func(pom
 	// 记录当前项目的pom文件信息
 	gavMap := map[string]*model.File{}
 	PathMap := map[string]*model.File{}
+	// 项目中的模块版本 key:groupId:artifactId
+	modules := map[string]string{}
 	for _, pom := range poms {
 		gavMap[pom.GAV()] = pom.File
 		pom.Update(&pom.PomDependency)
@@ -39,6 +41,9 @@ func ParsePoms(ctx context.Context, poms []*Pom, exclusion []*Pom, call func(pom
 		if pom.File.Relpath() != "" {
 			PathMap[pom.File.Relpath()] = pom.File
 		}
+		if pom.Check() {
+			modules[pom.GroupId+":"+pom.ArtifactId] = pom.Version
+		}
 	}
 
 	// 获取dependency对应的pom
@@ -52,22 +57,13 @@ func ParsePoms(ctx context.Context, poms []*Pom, exclusion []*Pom, call func(pom
 		}
 		var p *Pom
 		if ok {
-			f.OpenReader(func(reader io.Reader) {
-				p = ReadPom(reader)
-				p.File = f
-			})
+			p = ReadPomFile(f)
 		}
 		if p != nil {
 			return p
 		}
 		// 从组件仓库下载pom
-		var rs []common.RepoConfig
-		for _, urls := range repos {
-			for _, url := range urls {
-				rs = append(rs, common.RepoConfig{Url: url})
-			}
-		}
-		p = mavenOrigin(dep.GroupId, dep.ArtifactId, dep.Version, rs...)
+		p = mavenOrigin(dep.GroupId, dep.ArtifactId, dep.Version, repoConfigs(repos...)...)
 
 		if p == nil {
 			logs.Warnf("not found pom %s", dep.Index3())
@@ -92,12 +88,25 @@ func ParsePoms(ctx context.Context, poms []*Pom, exclusion []*Pom, call func(pom
 		wg.Add(1)
 		go func(pom *Pom) {
 			defer wg.Done()
-			call(pom, parsePom(ctx, pom, getpom))
+			call(pom, parsePom(ctx, pom, getpom, modules))
 		}(pom)
 	}
 	wg.Wait()
 }
 
+// ParseDependencies 借助pom解析依赖坐标列表的间接依赖 用于仅能获取直接依赖坐标的构建工具
+// path: 声明依赖的文件路径
+// deps: 直接依赖 scope为test的依赖为开发依赖
+// repos: 额外使用的maven仓库
+func ParseDependencies(ctx context.Context, path string, deps []*PomDependency, repos ...string) *model.DepGraph {
+	root := &model.DepGraph{Path: path}
+	virPom := &Pom{File: model.NewFile(path, path), Dependencies: deps, Repositories: repos}
+	ParsePoms(ctx, []*Pom{virPom}, nil, func(pom *Pom, pomResult *model.DepGraph) {
+		root = pomResult
+	})
+	return root
+}
+
 // inheritModules 继承modules属性
 func inheritModules(poms []*Pom) {
 
@@ -285,6 +294,26 @@ func inheritPom(pom *Pom, getpom getPomFunc) {
 	}
 }
 
+// repoConfigs 将pom中的仓库地址转换为仓库配置
+func repoConfigs(repos ...[]string) []common.RepoConfig {
+	var rs []common.RepoConfig
+	for _, urls := range repos {
+		for _, url := range urls {
+			rs = append(rs, common.RepoConfig{Url: url})
+		}
+	}
+	return rs
+}
+
+// mergeExclusions 合并exclusion 返回新的切片 避免修改原依赖的exclusion
+func mergeExclusions(exclusions ...[]*PomDependency) []*PomDependency {
+	var merged []*PomDependency
+	for _, e := range exclusions {
+		merged = append(merged, e...)
+	}
+	return merged
+}
+
 func replacePomDependency(old, new *PomDependency, indirect bool) (replaced *PomDependency) {
 	originVersion := old.Version
 	originScope := old.Scope
@@ -302,7 +331,9 @@ func replacePomDependency(old, new *PomDependency, indirect bool) (replaced *Pom
 }
 
 // parsePom 解析单个pom 返回该pom的依赖图
-func parsePom(ctx context.Context, pom *Pom, getpom getPomFunc) *model.DepGraph {
+// 按层级遍历依赖 同一组件保留路径最短的 路径长度相同时保留先声明的 被忽略的版本不再解析其子依赖(maven的nearest-wins规则)
+// modules: 项目中的模块版本 key:groupId:artifactId
+func parsePom(ctx context.Context, pom *Pom, getpom getPomFunc, modules map[string]string) *model.DepGraph {
 
 	// 补全nil值
 	if pom.Properties == nil {
@@ -379,7 +410,7 @@ func parsePom(ctx context.Context, pom *Pom, getpom getPomFunc) *model.DepGraph
 
 			// 使用当前pom的dependencyManagement补全
 			if d, ok := depManagement[dep.Index2()]; ok {
-				exclusion := append(dep.Exclusions, d.Exclusions...)
+				exclusion := mergeExclusions(dep.Exclusions, d.Exclusions)
 				if dep.Version == "" {
 					dep = replacePomDependency(dep, d, false)
 				}
@@ -391,13 +422,30 @@ func parsePom(ctx context.Context, pom *Pom, getpom getPomFunc) *model.DepGraph
 			if np != pom || dep.Version == "" {
 				d, ok := rootPomManagement[dep.Index2()]
 				if ok {
-					exclusion := append(dep.Exclusions, d.Exclusions...)
+					exclusion := mergeExclusions(dep.Exclusions, d.Exclusions)
 					dep = replacePomDependency(dep, d, true)
 					dep.Exclusions = exclusion
 					pom.Update(dep)
 				}
 			}
 
+			// 项目中的模块优先使用项目中的版本
+			if v, ok := modules[dep.GroupId+":"+dep.ArtifactId]; ok {
+				if dep.Version == "" || strings.Contains(dep.Version, "$") ||
+					IsVersionRange(dep.Version) && MatchVersionRange(v, dep.Version) {
+					d := *dep
+					d.Version = v
+					dep = &d
+				}
+			}
+
+			// 版本范围使用仓库中满足范围的最高版本
+			if IsVersionRange(dep.Version) {
+				d := *dep
+				d.Version = ResolveVersionRange(*dep, repoConfigs(np.Repositories, np.Mirrors)...)
+				dep = &d
+			}
+
 			// 查看是否在Exclusion列表中
 			if np.NeedExclusion(*dep) {
 				continue
@@ -422,7 +470,7 @@ func parsePom(ctx context.Context, pom *Pom, getpom getPomFunc) *model.DepGraph
 			if subpom := getpom(*dep, np.Repositories, np.Mirrors); subpom != nil {
 				subpom.PomDependency = *dep
 				// 继承根pom的exclusion
-				subpom.Exclusions = append(subpom.Exclusions, np.Exclusions...)
+				subpom.Exclusions = mergeExclusions(dep.Exclusions, np.Exclusions)
 				// 依赖继承parent
 				inheritPom(subpom, getpom)
 				sub.Expand = subpom
@@ -437,7 +485,9 @@ func parsePom(ctx context.Context, pom *Pom, getpom getPomFunc) *model.DepGraph
 	return root
 }
 
-var mavenOrigin = func(groupId, artifactId, version string, repos ...common.RepoConfig) *Pom {
+var mavenOrigin = defaultMavenOrigin
+
+func defaultMavenOrigin(groupId, artifactId, version string, repos ...common.RepoConfig) *Pom {
 
 	var p *Pom
 
@@ -475,6 +525,11 @@ func RegisterMavenOrigin(origin func(groupId, artifactId, version string) *Pom)
 	}
 }
 
+// ResetMavenOrigin 恢复默认的maven数据源
+func ResetMavenOrigin() {
+	mavenOrigin = defaultMavenOrigin
+}
+
 // DownloadPomFromRepo 从maven仓库下载pom
 // dep: pom的dependency内容
 // do: 对http.Response.Body的操作
@@ -487,7 +542,7 @@ func DownloadPomFromRepo(dep PomDependency, do func(r io.Reader), repos ...commo
 
 	// 正式版本
 	pom := fmt.Sprintf("%s/%s/%s/%s-%s.pom", strings.ReplaceAll(dep.GroupId, ".", "/"), dep.ArtifactId, dep.Version, dep.ArtifactId, dep.Version)
-	common.DownloadUrlFromRepos(pom, func(repo common.RepoConfig, r io.Reader) { do(r) }, append(defaultMavenRepo, repos...)...)
+	common.DownloadUrlFromRepos(pom, func(repo common.RepoConfig, r io.Reader) { do(r) }, mavenRepos(repos...)...)
 
 	// 快照版本
 	if !strings.HasSuffix(strings.ToLower(dep.Version), "-snapshot") {
@@ -521,7 +576,7 @@ func DownloadPomFromRepo(dep PomDependency, do func(r io.Reader), repos ...commo
 			}
 		}
 
-	}, append(defaultMavenRepo, repos...)...)
+	}, mavenRepos(repos...)...)
 
 }
 
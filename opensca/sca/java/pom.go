@@ -3,6 +3,7 @@ package java
 import (
 	"fmt"
 	"io"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -22,7 +23,7 @@ type Pom struct {
 	Repositories         []string         `xml:"repositories>repository>url"`
 	Mirrors              []string         `xml:"mirrors>mirror>url"`
 	Licenses             []string         `xml:"licenses>license>name"`
-	Profiles             []Pom            `xml:"profiles>profile"`
+	Profiles             []PomProfile     `xml:"profiles>profile"`
 	// 当前pom对应的文件信息
 	File *model.File `xml:"-" json:"-"`
 }
@@ -91,14 +92,15 @@ func (pp *PomProperties) UnmarshalXML(d *xml.Decoder, s xml.StartElement) error
 }
 
 // NeedExclusion 判断是否是当前依赖需要排除的子依赖
+// exclusion仅匹配groupId及artifactId 值为*时匹配任意值 例 *:* 排除全部子依赖
 func (pd PomDependency) NeedExclusion(dep PomDependency) bool {
 	check := func(s1, s2 string) bool {
+		s1 = strings.TrimSpace(s1)
 		return s1 == "" || s1 == "*" || s1 == s2
 	}
 	for _, e := range pd.Exclusions {
 		if check(e.GroupId, dep.GroupId) &&
-			check(e.ArtifactId, dep.ArtifactId) &&
-			check(e.Version, dep.Version) {
+			check(e.ArtifactId, dep.ArtifactId) {
 			return true
 		}
 	}
@@ -128,8 +130,30 @@ func (pd PomDependency) Index4() string {
 	return fmt.Sprintf("%s:%s", pd.Index3(), pd.Scope)
 }
 
-// ReadPom 读取pom信息
+// ReadPom 读取依赖的pom信息 profile仅按jdk、os及property等激活条件生效
 func ReadPom(reader io.Reader) *Pom {
+	return readPom(reader, "", false)
+}
+
+// ReadPomFile 读取项目中的pom文件 profile的文件激活条件相对于pom所在目录
+// 指定的profile及settings.xml中的profile属性仅作用于项目中的pom
+func ReadPomFile(file *model.File) *Pom {
+	dir, err := filepath.Abs(filepath.Dir(file.Abspath()))
+	if err != nil {
+		dir = filepath.Dir(file.Abspath())
+	}
+	var p *Pom
+	file.OpenReader(func(reader io.Reader) {
+		p = readPom(reader, dir, true)
+		p.File = file
+	})
+	return p
+}
+
+// readPom 读取pom信息
+// dir: pom所在目录
+// project: 是否为项目中的pom
+func readPom(reader io.Reader, dir string, project bool) *Pom {
 
 	data, err := io.ReadAll(reader)
 	if err != nil {
@@ -175,13 +199,18 @@ func ReadPom(reader io.Reader) *Pom {
 	p.Properties["project.parent.version"] = &Property{Key: "project.parent.version", Value: p.Parent.Version}
 	p.Properties["parent.version"] = &Property{Key: "parent.version", Value: p.Parent.Version}
 
-	// 添加第一个Profile
-	if len(p.Profiles) > 0 {
-		profile := p.Profiles[0]
+	// 合并生效的profile
+	for _, profile := range p.ActiveProfiles(dir, project) {
 		for k, v := range profile.Properties {
-			if _, ok := p.Properties[k]; !ok {
-				p.Properties[k] = v
-			}
+			v.Define = p
+			p.Properties[k] = v
+		}
+		for _, d := range profile.DependencyManagement {
+			d.Define = p
+		}
+		for _, d := range profile.Dependencies {
+			trimSpace(d)
+			d.Define = p
 		}
 		p.Dependencies = append(p.Dependencies, profile.Dependencies...)
 		p.DependencyManagement = append(p.DependencyManagement, profile.DependencyManagement...)
@@ -190,25 +219,17 @@ func ReadPom(reader io.Reader) *Pom {
 		p.Repositories = append(p.Repositories, profile.Repositories...)
 	}
 
-	// 处理版本范围
-	for _, d := range p.Dependencies {
-		if strings.ContainsAny(d.Version, "()[]") {
-			if !strings.Contains(d.Version, ",") {
-				d.Version = strings.Trim(d.Version, "()[]")
-			} else {
-				lr := strings.Split(strings.Split(d.Version, "!")[0], ",")
-				if len(lr) == 2 {
-					l, r := lr[0], lr[1]
-					if strings.HasPrefix(l, "[") {
-						d.Version = strings.TrimLeft(l, "[")
-					}
-					if strings.HasSuffix(r, "]") {
-						d.Version = strings.TrimRight(l, "]")
-					}
-				}
-			}
+	// 用户属性优先级高于settings.xml中的属性 均高于pom属性
+	profileMu.RLock()
+	if project {
+		for k, v := range settingsProperties {
+			p.Properties[k] = &Property{Key: k, Value: v}
 		}
 	}
+	for k, v := range userProperties {
+		p.Properties[k] = &Property{Key: k, Value: v}
+	}
+	profileMu.RUnlock()
 
 	// 存在厂商和组件相同的依赖时保留最后声明的
 	depSet := map[string]bool{}
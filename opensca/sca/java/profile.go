@@ -0,0 +1,287 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// PomProfile pom中的profile
+type PomProfile struct {
+	Id                   string           `xml:"id"`
+	Activation           PomActivation    `xml:"activation"`
+	Properties           PomProperties    `xml:"properties"`
+	DependencyManagement []*PomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	Dependencies         []*PomDependency `xml:"dependencies>dependency"`
+	Modules              []string         `xml:"modules>module"`
+	Repositories         []string         `xml:"repositories>repository>url"`
+	Mirrors              []string         `xml:"mirrors>mirror>url"`
+}
+
+// PomActivation profile激活条件 同时指定多个条件时需全部满足
+type PomActivation struct {
+	ActiveByDefault bool   `xml:"activeByDefault"`
+	Jdk             string `xml:"jdk"`
+	Os              struct {
+		Name   string `xml:"name"`
+		Family string `xml:"family"`
+		Arch   string `xml:"arch"`
+	} `xml:"os"`
+	Property struct {
+		Name  string `xml:"name"`
+		Value string `xml:"value"`
+	} `xml:"property"`
+	File struct {
+		Exists  string `xml:"exists"`
+		Missing string `xml:"missing"`
+	} `xml:"file"`
+}
+
+var (
+	profileMu sync.RWMutex
+	// 指定激活的profile
+	activeProfiles = map[string]bool{}
+	// 指定不激活的profile
+	inactiveProfiles = map[string]bool{}
+	// 用户属性 用于property激活条件 同时覆盖pom中的同名属性 同mvn -D
+	userProperties = map[string]string{}
+	// settings.xml中生效的profile的属性 仅覆盖项目pom中的同名属性
+	settingsProperties = map[string]string{}
+)
+
+// RegisterMavenProfiles 设置需要激活的profile 同mvn -P 以!或-开头表示不激活
+func RegisterMavenProfiles(profiles ...string) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	for _, id := range profiles {
+		for _, id := range strings.Split(id, ",") {
+			id = strings.TrimSpace(id)
+			if strings.HasPrefix(id, "!") || strings.HasPrefix(id, "-") {
+				inactiveProfiles[id[1:]] = true
+			} else if id != "" {
+				activeProfiles[strings.TrimPrefix(id, "+")] = true
+			}
+		}
+	}
+}
+
+// RegisterMavenProperties 设置用户属性 同mvn -D
+func RegisterMavenProperties(properties map[string]string) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	for k, v := range properties {
+		userProperties[k] = v
+	}
+}
+
+// ResetMavenProfiles 清除指定的profile及用户属性
+func ResetMavenProfiles() {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	activeProfiles = map[string]bool{}
+	inactiveProfiles = map[string]bool{}
+	userProperties = map[string]string{}
+}
+
+// property 获取属性 env.开头的属性读取环境变量
+func property(key string) (string, bool) {
+	if env, ok := strings.CutPrefix(key, "env."); ok {
+		return os.LookupEnv(env)
+	}
+	profileMu.RLock()
+	v, ok := userProperties[key]
+	profileMu.RUnlock()
+	return v, ok
+}
+
+// negate 去除取反标记 例 !1.8
+func negate(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "!") {
+		return strings.TrimSpace(s[1:]), true
+	}
+	return s, false
+}
+
+// jdkVersionReg JAVA_HOME/release中的版本 例 JAVA_VERSION="17.0.2"
+var jdkVersionReg = regexp.MustCompile(`JAVA_VERSION="([^"]+)"`)
+
+// jdkVersion 当前jdk版本 优先使用java.version属性 其次读取JAVA_HOME/release
+func jdkVersion() string {
+	if v, ok := property("java.version"); ok {
+		return v
+	}
+	if home := os.Getenv("JAVA_HOME"); home != "" {
+		if data, err := os.ReadFile(filepath.Join(home, "release")); err == nil {
+			if m := jdkVersionReg.FindSubmatch(data); m != nil {
+				return string(m[1])
+			}
+		}
+	}
+	return ""
+}
+
+// matchJdk 例 1.8 | !1.8 | [1.8,) 前缀匹配时1.8可匹配1.8.0_292
+func matchJdk(jdk string) bool {
+	version := jdkVersion()
+	if version == "" {
+		return false
+	}
+	if IsVersionRange(jdk) {
+		return MatchVersionRange(version, jdk)
+	}
+	jdk, not := negate(jdk)
+	return strings.HasPrefix(version, jdk) != not
+}
+
+// osArch 当前系统架构 与java的os.arch一致
+func osArch() string {
+	switch runtime.GOARCH {
+	case "386":
+		return "x86"
+	case "arm64":
+		return "aarch64"
+	}
+	return runtime.GOARCH
+}
+
+// matchOs 匹配操作系统名称、系统族及架构
+func matchOs(activation PomActivation) bool {
+	check := func(expect string, match func(string) bool) bool {
+		if strings.TrimSpace(expect) == "" {
+			return true
+		}
+		expect, not := negate(strings.ToLower(expect))
+		return match(expect) != not
+	}
+	return check(activation.Os.Name, func(name string) bool {
+		// os.name 例 linux | mac os x | windows 10
+		osName := runtime.GOOS
+		if osName == "darwin" {
+			osName = "mac os x"
+		}
+		return strings.HasPrefix(name, osName) || strings.HasPrefix(osName, name)
+	}) && check(activation.Os.Family, func(family string) bool {
+		switch family {
+		case "windows", "dos":
+			return runtime.GOOS == "windows"
+		case "mac":
+			return runtime.GOOS == "darwin"
+		case "unix":
+			return runtime.GOOS != "windows"
+		}
+		return family == runtime.GOOS
+	}) && check(activation.Os.Arch, func(arch string) bool {
+		return arch == osArch() || arch == runtime.GOARCH || arch == "x86_64" && runtime.GOARCH == "amd64"
+	})
+}
+
+// matchProperty 例 name | !name | name=value | name=!value
+func matchProperty(activation PomActivation) bool {
+	name, not := negate(activation.Property.Name)
+	value, ok := property(name)
+	if not {
+		return !ok
+	}
+	if activation.Property.Value == "" {
+		return ok
+	}
+	expect, notValue := negate(activation.Property.Value)
+	return (ok && value == expect) != notValue
+}
+
+// matchFile 判断文件是否存在 dir: pom所在目录 为空时相对路径无法判断
+func matchFile(activation PomActivation, dir string) bool {
+	exist := func(path string) (bool, bool) {
+		path = strings.NewReplacer("${basedir}", dir, "${project.basedir}", dir).Replace(strings.TrimSpace(path))
+		if !filepath.IsAbs(path) {
+			if dir == "" {
+				return false, false
+			}
+			path = filepath.Join(dir, path)
+		}
+		_, err := os.Stat(path)
+		return err == nil, true
+	}
+	if activation.File.Exists != "" {
+		if ok, known := exist(activation.File.Exists); !known || !ok {
+			return false
+		}
+	}
+	if activation.File.Missing != "" {
+		if ok, known := exist(activation.File.Missing); !known || ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Active 判断profile是否满足激活条件 不含activeByDefault
+// dir: pom所在目录
+func (a PomActivation) Active(dir string) bool {
+	conditions := 0
+	if strings.TrimSpace(a.Jdk) != "" {
+		conditions++
+		if !matchJdk(a.Jdk) {
+			return false
+		}
+	}
+	if a.Os.Name+a.Os.Family+a.Os.Arch != "" {
+		conditions++
+		if !matchOs(a) {
+			return false
+		}
+	}
+	if strings.TrimSpace(a.Property.Name) != "" {
+		conditions++
+		if !matchProperty(a) {
+			return false
+		}
+	}
+	if a.File.Exists+a.File.Missing != "" {
+		conditions++
+		if !matchFile(a, dir) {
+			return false
+		}
+	}
+	return conditions > 0
+}
+
+// ActiveProfiles 当前生效的profile
+// 指定激活或满足激活条件的profile生效 均不存在时activeByDefault的profile生效
+// dir: pom所在目录
+// project: 是否为项目中的pom 指定激活或不激活的profile仅作用于项目中的pom
+func (p *Pom) ActiveProfiles(dir string, project bool) []*PomProfile {
+
+	// 复制指定的profile 避免判断激活条件时重复加锁
+	explicit, disabled := map[string]bool{}, map[string]bool{}
+	if project {
+		profileMu.RLock()
+		for _, profile := range p.Profiles {
+			explicit[profile.Id] = activeProfiles[profile.Id]
+			disabled[profile.Id] = inactiveProfiles[profile.Id]
+		}
+		profileMu.RUnlock()
+	}
+
+	var actives, defaults []*PomProfile
+	for i := range p.Profiles {
+		profile := &p.Profiles[i]
+		switch {
+		case disabled[profile.Id]:
+			continue
+		case explicit[profile.Id], profile.Activation.Active(dir):
+			actives = append(actives, profile)
+		case profile.Activation.ActiveByDefault:
+			defaults = append(defaults, profile)
+		}
+	}
+
+	if len(actives) == 0 {
+		return defaults
+	}
+	return actives
+}
@@ -52,11 +52,9 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 	poms := []*Pom{}
 	for _, file := range files {
 		if filter.JavaPom(file.Relpath()) {
-			file.OpenReader(func(reader io.Reader) {
-				pom := ReadPom(reader)
-				pom.File = file
+			if pom := ReadPomFile(file); pom != nil {
 				poms = append(poms, pom)
-			})
+			}
 		}
 	}
 
@@ -87,9 +85,13 @@ var defaultMavenRepo = []common.RepoConfig{
 	{Url: "https://repo1.maven.org/maven2"},
 }
 
+// customMavenRepo 默认仓库是否为用户配置的仓库 用户配置的仓库不会被镜像替代
+var customMavenRepo bool
+
 func RegisterMavenRepo(repos ...common.RepoConfig) {
 	newRepo := common.TrimRepo(repos...)
 	if len(newRepo) > 0 {
 		defaultMavenRepo = newRepo
+		customMavenRepo = true
 	}
 }
@@ -0,0 +1,317 @@
+package java
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/common"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java/xml"
+)
+
+// MavenSettings maven的settings.xml
+type MavenSettings struct {
+	LocalRepository string `xml:"localRepository"`
+	Mirrors         []struct {
+		Id       string `xml:"id"`
+		MirrorOf string `xml:"mirrorOf"`
+		Url      string `xml:"url"`
+	} `xml:"mirrors>mirror"`
+	Servers []struct {
+		Id       string `xml:"id"`
+		Username string `xml:"username"`
+		Password string `xml:"password"`
+	} `xml:"servers>server"`
+	Profiles       []SettingsProfile `xml:"profiles>profile"`
+	ActiveProfiles []string          `xml:"activeProfiles>activeProfile"`
+}
+
+// SettingsProfile settings.xml中的profile
+type SettingsProfile struct {
+	Id           string        `xml:"id"`
+	Activation   PomActivation `xml:"activation"`
+	Properties   PomProperties `xml:"properties"`
+	Repositories []struct {
+		Id  string `xml:"id"`
+		Url string `xml:"url"`
+	} `xml:"repositories>repository"`
+}
+
+// settingsEnvReg settings.xml中引用的环境变量及用户目录 例 ${env.NEXUS_USER} | ${user.home}
+var settingsEnvReg = regexp.MustCompile(`\$\{(env\.\w+|user\.home)\}`)
+
+// ReadMavenSettings 读取settings.xml
+func ReadMavenSettings(reader io.Reader) *MavenSettings {
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		logs.Warn(err)
+		return nil
+	}
+
+	data = settingsEnvReg.ReplaceAllFunc(data, func(b []byte) []byte {
+		key := string(b[2 : len(b)-1])
+		if key == "user.home" {
+			if u, err := user.Current(); err == nil {
+				return []byte(u.HomeDir)
+			}
+			return b
+		}
+		return []byte(os.Getenv(strings.TrimPrefix(key, "env.")))
+	})
+
+	settings := &MavenSettings{}
+	if err := xml.Unmarshal(data, settings); err != nil {
+		logs.Warn(err)
+		return nil
+	}
+	return settings
+}
+
+// server 仓库id对应的认证信息
+func (s *MavenSettings) server(id, url string) common.RepoConfig {
+	repo := common.RepoConfig{Url: strings.TrimSpace(url)}
+	for _, server := range s.Servers {
+		if server.Id != id {
+			continue
+		}
+		repo.Username = strings.TrimSpace(server.Username)
+		repo.Password = strings.TrimSpace(server.Password)
+		// 加密的密码需要settings-security.xml解密
+		if strings.HasPrefix(repo.Password, "{") && strings.HasSuffix(repo.Password, "}") {
+			logs.Warnf("encrypted password of server %s is not supported", id)
+		}
+	}
+	return repo
+}
+
+// activeSettingsProfiles 生效的profile 在activeProfiles中或满足激活条件
+func (s *MavenSettings) activeSettingsProfiles() []SettingsProfile {
+	active := map[string]bool{}
+	for _, id := range s.ActiveProfiles {
+		active[strings.TrimSpace(id)] = true
+	}
+	var profiles []SettingsProfile
+	for _, profile := range s.Profiles {
+		if active[profile.Id] || profile.Activation.ActiveByDefault || profile.Activation.Active("") {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// externalRepo 是否为外部仓库 即非本机及非文件仓库
+func externalRepo(repoUrl string) bool {
+	u, err := url.Parse(strings.TrimSpace(repoUrl))
+	if err != nil || u.Scheme == "file" {
+		return false
+	}
+	host := u.Hostname()
+	return host != "" && host != "localhost" && host != "127.0.0.1"
+}
+
+// matchMirrorOf 仓库是否匹配镜像的mirrorOf
+// 例 * | external:* | external:http:* | central,snapshots | *,!private
+// id: 仓库id 未知时为空 仅匹配通配规则
+func matchMirrorOf(mirrorOf, id, repoUrl string) bool {
+	matched := false
+	for _, p := range strings.Split(mirrorOf, ",") {
+		p = strings.TrimSpace(p)
+		switch {
+		case strings.HasPrefix(p, "!"):
+			if id != "" && p[1:] == id {
+				return false
+			}
+		case p == "*":
+			matched = true
+		case p == "external:*":
+			matched = matched || externalRepo(repoUrl)
+		case p == "external:http:*":
+			matched = matched || externalRepo(repoUrl) && strings.HasPrefix(repoUrl, "http:")
+		case id != "" && p == id:
+			matched = true
+		}
+	}
+	return matched
+}
+
+// MirrorOf 仓库是否被settings.xml中的镜像替代
+// id: 仓库id 默认仓库为central 未知时为空
+func (s *MavenSettings) MirrorOf(id, repoUrl string) bool {
+	for _, mirror := range s.Mirrors {
+		if matchMirrorOf(mirror.MirrorOf, id, repoUrl) {
+			return true
+		}
+	}
+	return false
+}
+
+// LocalRepo 本地仓库目录 未配置时为~/.m2/repository
+func (s *MavenSettings) LocalRepo() string {
+	if local := strings.TrimSpace(s.LocalRepository); local != "" {
+		return local
+	}
+	if u, err := user.Current(); err == nil {
+		return filepath.Join(u.HomeDir, ".m2", "repository")
+	}
+	return ""
+}
+
+// Repos settings.xml中配置的远程仓库 依次为镜像仓库及生效的profile中未被镜像替代的仓库
+func (s *MavenSettings) Repos() []common.RepoConfig {
+
+	var repos []common.RepoConfig
+
+	for _, mirror := range s.Mirrors {
+		repos = append(repos, s.server(mirror.Id, mirror.Url))
+	}
+
+	for _, profile := range s.activeSettingsProfiles() {
+		for _, repo := range profile.Repositories {
+			if !s.MirrorOf(repo.Id, repo.Url) {
+				repos = append(repos, s.server(repo.Id, repo.Url))
+			}
+		}
+	}
+
+	return common.TrimRepo(repos...)
+}
+
+var (
+	// settingsMavenRepo settings.xml中配置的maven仓库 优先于默认仓库使用
+	settingsMavenRepo []common.RepoConfig
+	// mavenSettings 已读取的settings.xml 用于判断仓库是否被镜像替代
+	mavenSettings []*MavenSettings
+	// useLocalRepo 是否优先使用本地仓库
+	useLocalRepo bool
+)
+
+// RegisterMavenLocalRepo 设置是否优先从本地仓库获取pom 默认不使用
+// 本地仓库为settings.xml中的localRepository 未配置时为~/.m2/repository
+func RegisterMavenLocalRepo(enable bool) {
+	useLocalRepo = enable
+}
+
+// localMavenRepo 本地仓库 不存在时为空
+func localMavenRepo() []common.RepoConfig {
+	settings := &MavenSettings{}
+	for _, s := range mavenSettings {
+		if strings.TrimSpace(s.LocalRepository) != "" {
+			settings = s
+			break
+		}
+	}
+	local := settings.LocalRepo()
+	if info, err := os.Stat(local); err != nil || !info.IsDir() {
+		return nil
+	}
+	return []common.RepoConfig{{Url: "file://" + filepath.ToSlash(local)}}
+}
+
+// mirrored 仓库是否被已读取的settings.xml中的镜像替代
+func mirrored(id, repoUrl string) bool {
+	for _, s := range mavenSettings {
+		if s.MirrorOf(id, repoUrl) {
+			return true
+		}
+	}
+	return false
+}
+
+// mavenRepos 获取pom时使用的仓库 依次为本地仓库、settings.xml中的仓库、默认仓库及pom中的仓库
+// 内置的默认仓库视为central pom中的仓库没有记录id 被镜像替代的仓库不再使用
+func mavenRepos(repos ...common.RepoConfig) []common.RepoConfig {
+	var rs []common.RepoConfig
+	if useLocalRepo {
+		rs = append(rs, localMavenRepo()...)
+	}
+	rs = append(rs, settingsMavenRepo...)
+	for _, r := range defaultMavenRepo {
+		if customMavenRepo || !mirrored("central", r.Url) {
+			rs = append(rs, r)
+		}
+	}
+	for _, r := range repos {
+		if !mirrored("", r.Url) {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// defaultSettingsPath 默认的settings.xml 用户配置优先于全局配置
+func defaultSettingsPath() []string {
+	var paths []string
+	if u, err := user.Current(); err == nil {
+		paths = append(paths, filepath.Join(u.HomeDir, ".m2", "settings.xml"))
+	}
+	for _, env := range []string{"MAVEN_HOME", "M2_HOME"} {
+		if home := os.Getenv(env); home != "" {
+			paths = append(paths, filepath.Join(home, "conf", "settings.xml"))
+		}
+	}
+	return paths
+}
+
+// RegisterMavenSettings 读取settings.xml中的仓库及profile
+// 生效的profile中的属性及activeProfiles仅作用于项目中的pom
+// paths: settings.xml路径 为空时读取~/.m2/settings.xml及MAVEN_HOME/conf/settings.xml
+func RegisterMavenSettings(paths ...string) {
+
+	var files []string
+	for _, path := range paths {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	if len(files) == 0 {
+		files = defaultSettingsPath()
+	}
+
+	for _, path := range files {
+
+		f, err := os.Open(path)
+		if err != nil {
+			logs.Debug(err)
+			continue
+		}
+		settings := ReadMavenSettings(f)
+		f.Close()
+		if settings == nil {
+			continue
+		}
+		logs.Debugf("load maven settings %s", path)
+
+		RegisterMavenProfiles(settings.ActiveProfiles...)
+
+		profiles := settings.activeSettingsProfiles()
+		profileMu.Lock()
+		for _, profile := range profiles {
+			for k, v := range profile.Properties {
+				// 先读取的settings.xml优先
+				if _, ok := settingsProperties[k]; !ok {
+					settingsProperties[k] = strings.TrimSpace(v.Value)
+				}
+			}
+		}
+		profileMu.Unlock()
+
+		mavenSettings = append(mavenSettings, settings)
+		settingsMavenRepo = append(settingsMavenRepo, settings.Repos()...)
+	}
+}
+
+// ResetMavenSettings 清除已读取的settings.xml及本地仓库配置
+func ResetMavenSettings() {
+	profileMu.Lock()
+	settingsProperties = map[string]string{}
+	profileMu.Unlock()
+	settingsMavenRepo = nil
+	mavenSettings = nil
+	useLocalRepo = false
+}
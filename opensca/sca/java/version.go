@@ -0,0 +1,285 @@
+package java
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/common"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java/xml"
+)
+
+// versionItem maven版本号中的单个片段
+type versionItem struct {
+	number *big.Int
+	// 非数字片段 统一为小写
+	qualifier string
+}
+
+// qualifierOrder 常见限定符的顺序 未列出的限定符排在sp之后并按字典序比较
+var qualifierOrder = map[string]int{
+	"alpha":     0,
+	"a":         0,
+	"beta":      1,
+	"b":         1,
+	"milestone": 2,
+	"m":         2,
+	"rc":        3,
+	"cr":        3,
+	"snapshot":  4,
+	"":          5,
+	"ga":        5,
+	"final":     5,
+	"release":   5,
+	"sp":        6,
+}
+
+// splitVersion 拆分版本号 以.和-以及数字与字母的交界处分隔 例 1.0-RC1 => 1 0 rc 1
+func splitVersion(version string) []versionItem {
+	var items []versionItem
+	add := func(s string) {
+		if s == "" {
+			return
+		}
+		if n, ok := new(big.Int).SetString(s, 10); ok {
+			items = append(items, versionItem{number: n})
+		} else {
+			items = append(items, versionItem{qualifier: strings.ToLower(s)})
+		}
+	}
+	start := 0
+	for i, c := range version {
+		if c == '.' || c == '-' || c == '_' {
+			add(version[start:i])
+			start = i + 1
+			continue
+		}
+		if i > start && unicode.IsDigit(c) != unicode.IsDigit(rune(version[i-1])) {
+			add(version[start:i])
+			start = i
+		}
+	}
+	add(version[start:])
+	// 去除末尾的0及空限定符 例 1.0.0 => 1
+	for len(items) > 0 {
+		last := items[len(items)-1]
+		if last.number != nil && last.number.Sign() == 0 || last.number == nil && qualifierOrder[last.qualifier] == 5 && last.qualifier != "" {
+			items = items[:len(items)-1]
+			continue
+		}
+		break
+	}
+	return items
+}
+
+// compareItem 比较版本片段 数字>限定符 缺失的片段视为0或空限定符
+func compareItem(a, b *versionItem) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -compareItem(b, nil)
+	case a.number != nil:
+		if b == nil {
+			return a.number.Sign()
+		}
+		if b.number == nil {
+			return 1
+		}
+		return a.number.Cmp(b.number)
+	default:
+		if b != nil && b.number != nil {
+			return -1
+		}
+		var bq string
+		if b != nil {
+			bq = b.qualifier
+		}
+		ao, aok := qualifierOrder[a.qualifier]
+		bo, bok := qualifierOrder[bq]
+		switch {
+		case aok && bok:
+			return ao - bo
+		case aok:
+			return -1
+		case bok:
+			return 1
+		}
+		return strings.Compare(a.qualifier, bq)
+	}
+}
+
+// CompareVersion 按maven规则比较版本号 a<b返回负数 a=b返回0 a>b返回正数
+func CompareVersion(a, b string) int {
+	ai, bi := splitVersion(a), splitVersion(b)
+	for i := 0; i < len(ai) || i < len(bi); i++ {
+		var x, y *versionItem
+		if i < len(ai) {
+			x = &ai[i]
+		}
+		if i < len(bi) {
+			y = &bi[i]
+		}
+		if c := compareItem(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// versionRestriction 版本范围中的一个区间 上下界为空时表示无界
+type versionRestriction struct {
+	lower, upper                   string
+	lowerInclusive, upperInclusive bool
+}
+
+func (r versionRestriction) contains(version string) bool {
+	if r.lower != "" {
+		c := CompareVersion(version, r.lower)
+		if c < 0 || c == 0 && !r.lowerInclusive {
+			return false
+		}
+	}
+	if r.upper != "" {
+		c := CompareVersion(version, r.upper)
+		if c > 0 || c == 0 && !r.upperInclusive {
+			return false
+		}
+	}
+	return true
+}
+
+// IsVersionRange 是否为版本范围 例 [1.0,2.0) | [1.0] | (,1.0],[1.2,)
+func IsVersionRange(version string) bool {
+	return strings.HasPrefix(version, "[") || strings.HasPrefix(version, "(")
+}
+
+// parseVersionRange 解析版本范围 多个区间以逗号分隔
+func parseVersionRange(version string) []versionRestriction {
+	var rs []versionRestriction
+	for version != "" {
+		end := strings.IndexAny(version, ")]")
+		if end == -1 || !IsVersionRange(version) {
+			break
+		}
+		r := versionRestriction{
+			lowerInclusive: version[0] == '[',
+			upperInclusive: version[end] == ']',
+		}
+		lower, upper, ok := strings.Cut(version[1:end], ",")
+		r.lower = strings.TrimSpace(lower)
+		if ok {
+			r.upper = strings.TrimSpace(upper)
+		} else {
+			// [1.0] 为固定版本
+			r.upper = r.lower
+		}
+		rs = append(rs, r)
+		version = strings.TrimLeft(version[end+1:], ", ")
+	}
+	return rs
+}
+
+// MatchVersionRange 判断版本是否满足版本范围
+func MatchVersionRange(version, versionRange string) bool {
+	for _, r := range parseVersionRange(versionRange) {
+		if r.contains(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// fallbackVersion 无法获取版本列表时使用的版本 优先使用闭区间的下界 其次为闭区间的上界
+func fallbackVersion(versionRange string) string {
+	rs := parseVersionRange(versionRange)
+	for _, r := range rs {
+		if r.lower != "" && r.lowerInclusive {
+			return r.lower
+		}
+	}
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i].upper != "" && rs[i].upperInclusive {
+			return rs[i].upper
+		}
+	}
+	for _, r := range rs {
+		if r.lower != "" {
+			return r.lower
+		}
+	}
+	return ""
+}
+
+// ResolveVersionRange 使用maven-metadata.xml中的版本列表解析版本范围 返回满足范围的最高版本
+// 无法获取版本列表时回退为范围边界
+func ResolveVersionRange(dep PomDependency, repos ...common.RepoConfig) string {
+	var version string
+	for _, v := range mavenMetadataOrigin(dep.GroupId, dep.ArtifactId, repos...) {
+		if MatchVersionRange(v, dep.Version) && (version == "" || CompareVersion(v, version) > 0) {
+			version = v
+		}
+	}
+	if version == "" {
+		version = fallbackVersion(dep.Version)
+	}
+	return version
+}
+
+// metadataCache key:groupId:artifactId value:版本列表
+var metadataCache = sync.Map{}
+
+var mavenMetadataOrigin = defaultMavenMetadataOrigin
+
+func defaultMavenMetadataOrigin(groupId, artifactId string, repos ...common.RepoConfig) []string {
+
+	key := fmt.Sprintf("%s:%s", groupId, artifactId)
+	if versions, ok := metadataCache.Load(key); ok {
+		return versions.([]string)
+	}
+
+	var versions []string
+	metadata := fmt.Sprintf("%s/%s/maven-metadata.xml", strings.ReplaceAll(groupId, ".", "/"), artifactId)
+	common.DownloadUrlFromRepos(metadata, func(repo common.RepoConfig, r io.Reader) {
+		versions = ReadMavenMetadata(r)
+	}, mavenRepos(repos...)...)
+
+	metadataCache.Store(key, versions)
+	return versions
+}
+
+// ReadMavenMetadata 读取maven-metadata.xml中的版本列表
+func ReadMavenMetadata(reader io.Reader) []string {
+	metadata := struct {
+		Versions []string `xml:"versioning>versions>version"`
+	}{}
+	if err := xml.NewDecoder(reader).Decode(&metadata); err != nil {
+		logs.Warn(err)
+	}
+	var versions []string
+	for _, v := range metadata.Versions {
+		if v = strings.TrimSpace(v); v != "" {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// RegisterMavenMetadataOrigin 注册maven-metadata数据源
+// origin: 获取组件的全部版本 ga=>versions
+func RegisterMavenMetadataOrigin(origin func(groupId, artifactId string) []string) {
+	if origin != nil {
+		mavenMetadataOrigin = func(groupId, artifactId string, repos ...common.RepoConfig) []string {
+			return origin(groupId, artifactId)
+		}
+	}
+}
+
+// ResetMavenMetadataOrigin 恢复默认的maven-metadata数据源
+func ResetMavenMetadataOrigin() {
+	mavenMetadataOrigin = defaultMavenMetadataOrigin
+}
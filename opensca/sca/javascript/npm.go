@@ -19,7 +19,10 @@ type PackageJson struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
 	// License              string            `json:"license"`
-	Develop bool `json:"dev"` // lock v3
+	Develop bool `json:"dev"`  // lock v3
+	Link    bool `json:"link"` // lock v2+ 指向本地目录(workspace)的组件
+	// workspace成员目录规则
+	Workspaces workspaces `json:"workspaces"`
 	// TODO 只有依赖冲突时才会使用
 	Resolutions          map[string]string `json:"resolutions"`
 	Dependencies         map[string]string `json:"dependencies"`
@@ -268,6 +271,24 @@ func ParsePackageJsonWithLockV3(pkgjson *PackageJson, pkglock *PackageLock) *mod
 		return nil
 	}
 
+	return parsePackageJsonWithPackages(pkgjson, pkglock, "")
+}
+
+// ParseWorkspaceWithLock 借助workspace根目录的package.lock(v2+)解析workspace成员的package.json
+// member: 成员目录相对package.lock所在目录的路径
+func ParseWorkspaceWithLock(pkgjson *PackageJson, pkglock *PackageLock, member string) *model.DepGraph {
+
+	if len(pkglock.Packages) == 0 {
+		return nil
+	}
+
+	return parsePackageJsonWithPackages(pkgjson, pkglock, member)
+}
+
+// parsePackageJsonWithPackages 借助package.lock的packages字段解析package.json
+// basedir: package.json所在目录相对package.lock所在目录的路径
+func parsePackageJsonWithPackages(pkgjson *PackageJson, pkglock *PackageLock, basedir string) *model.DepGraph {
+
 	for jspath, js := range pkglock.Packages {
 		if js.File == nil {
 			js.File = model.NewFile("", jspath)
@@ -281,13 +302,14 @@ func ParsePackageJsonWithLockV3(pkgjson *PackageJson, pkglock *PackageLock) *mod
 		js   *PackageJson
 		path string
 	}
-	root.Expand = expand{js: pkgjson, path: ""}
+	root.Expand = expand{js: pkgjson, path: basedir}
 
 	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph { return &model.DepGraph{Name: s[0], Version: s[1]} }).LoadOrStore
 
 	findDep := func(name, basedir string) *model.DepGraph {
 		jspath, subjs := findFromNodeModules(name, basedir, pkglock.Packages)
-		if subjs == nil {
+		// 跳过workspace中的其他项目
+		if subjs == nil || subjs.Link {
 			return nil
 		}
 		dep := _dep(name, subjs.Version, subjs.File.Relpath())
@@ -393,10 +415,9 @@ func FindMaxVersion(version string, versions []string) string {
 func findFromNodeModules(name, basedir string, nodePathMap map[string]*PackageJson) (jspath string, js *PackageJson) {
 	const node_modules = "node_modules"
 	paths := strings.Split(strings.ReplaceAll(basedir, `\`, `/`), "/")
-	for i := range paths {
-		tail := len(paths) - i
-		dirs := paths[:tail]
-		if paths[tail-1] != node_modules {
+	for tail := len(paths); tail >= 0; tail-- {
+		dirs := append([]string{}, paths[:tail]...)
+		if tail == 0 || paths[tail-1] != node_modules {
 			dirs = append(dirs, node_modules)
 		}
 		dirs = append(dirs, name)
@@ -0,0 +1,319 @@
+package javascript
+
+import (
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"gopkg.in/yaml.v3"
+)
+
+// PnpmLock pnpm-lock.yaml文件结构 兼容v5/v6/v9
+type PnpmLock struct {
+	LockfileVersion string
+	// 项目依赖 key:项目相对pnpm-lock.yaml所在目录的路径
+	Importers map[string]*PnpmImporter
+	// 锁定的组件 key:name@version(含peer后缀)
+	Packages map[string]*PnpmPackage
+}
+
+// PnpmImporter pnpm项目直接依赖 value:锁定版本引用
+type PnpmImporter struct {
+	Dependencies         map[string]string
+	DevDependencies      map[string]string
+	OptionalDependencies map[string]string
+}
+
+// PnpmPackage pnpm锁定的组件
+type PnpmPackage struct {
+	Name    string
+	Version string
+	// 是否为开发组件 nil代表lock中未标记(同时被开发及生产依赖或v9)
+	Dev      *bool
+	Optional bool
+	// 子依赖 value:锁定版本引用
+	Dependencies         map[string]string
+	OptionalDependencies map[string]string
+}
+
+// pnpmVersion 兼容v5的版本字符串及v6+的{specifier,version}结构
+type pnpmVersion string
+
+func (v *pnpmVersion) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		ver := struct {
+			Version string `yaml:"version"`
+		}{}
+		if err := node.Decode(&ver); err != nil {
+			return err
+		}
+		*v = pnpmVersion(ver.Version)
+		return nil
+	}
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return err
+	}
+	*v = pnpmVersion(s)
+	return nil
+}
+
+type pnpmLockImporter struct {
+	Dependencies         map[string]pnpmVersion `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmVersion `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmVersion `yaml:"optionalDependencies"`
+}
+
+type pnpmLockPackage struct {
+	Name                 string            `yaml:"name"`
+	Version              string            `yaml:"version"`
+	Dev                  *bool             `yaml:"dev"`
+	Optional             bool              `yaml:"optional"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+type pnpmLockFile struct {
+	LockfileVersion  string                       `yaml:"lockfileVersion"`
+	Importers        map[string]*pnpmLockImporter `yaml:"importers"`
+	pnpmLockImporter `yaml:",inline"`
+	Packages         map[string]*pnpmLockPackage `yaml:"packages"`
+	// v9 依赖关系记录在snapshots中
+	Snapshots map[string]*pnpmLockPackage `yaml:"snapshots"`
+}
+
+// ParsePnpmLock 解析pnpm-lock.yaml文件结构
+func ParsePnpmLock(file *model.File) *PnpmLock {
+
+	var raw pnpmLockFile
+	file.OpenReader(func(reader io.Reader) {
+		if err := yaml.NewDecoder(reader).Decode(&raw); err != nil && err != io.EOF {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	if raw.LockfileVersion == "" {
+		return nil
+	}
+
+	lock := &PnpmLock{
+		LockfileVersion: raw.LockfileVersion,
+		Importers:       map[string]*PnpmImporter{},
+		Packages:        map[string]*PnpmPackage{},
+	}
+
+	toMap := func(m map[string]pnpmVersion) map[string]string {
+		res := map[string]string{}
+		for k, v := range m {
+			res[k] = string(v)
+		}
+		return res
+	}
+
+	// 非workspace项目(v5/v6)的直接依赖记录在顶层
+	if len(raw.Importers) == 0 {
+		raw.Importers = map[string]*pnpmLockImporter{".": &raw.pnpmLockImporter}
+	}
+	for dir, imp := range raw.Importers {
+		if imp == nil {
+			continue
+		}
+		lock.Importers[path.Clean(dir)] = &PnpmImporter{
+			Dependencies:         toMap(imp.Dependencies),
+			DevDependencies:      toMap(imp.DevDependencies),
+			OptionalDependencies: toMap(imp.OptionalDependencies),
+		}
+	}
+
+	v5 := lock.v5()
+
+	pkgs := raw.Packages
+	if len(raw.Snapshots) > 0 {
+		pkgs = raw.Snapshots
+	}
+
+	for key, p := range pkgs {
+		if p == nil {
+			p = &pnpmLockPackage{}
+		}
+		name, version := pnpmSplitKey(key, v5)
+		pkg := &PnpmPackage{
+			Name:                 name,
+			Version:              pnpmTrimPeer(version, v5),
+			Dev:                  p.Dev,
+			Optional:             p.Optional,
+			Dependencies:         p.Dependencies,
+			OptionalDependencies: p.OptionalDependencies,
+		}
+		// 非npm仓库的组件在packages中记录实际的名称及版本
+		info := p
+		if len(raw.Snapshots) > 0 {
+			info = raw.Packages[name+"@"+pnpmTrimPeer(version, v5)]
+		}
+		if info != nil {
+			if info.Name != "" {
+				pkg.Name = info.Name
+			}
+			if info.Version != "" {
+				pkg.Version = info.Version
+			}
+		}
+		lock.Packages[name+"@"+version] = pkg
+	}
+
+	return lock
+}
+
+// v5 lockfileVersion小于6时组件标识使用/name/version格式
+func (lock *PnpmLock) v5() bool {
+	v, err := strconv.ParseFloat(lock.LockfileVersion, 64)
+	return err == nil && v < 6
+}
+
+// find 查找依赖引用的组件
+// name: 依赖名
+// ref: 锁定版本引用 例 1.0.0 | 1.0.0(peer@1.0.0) | /alias/1.0.0 | alias@1.0.0
+func (lock *PnpmLock) find(name, ref string) *PnpmPackage {
+	// workspace中的项目
+	if strings.HasPrefix(ref, "link:") {
+		return nil
+	}
+	if pkg, ok := lock.Packages[name+"@"+ref]; ok {
+		return pkg
+	}
+	// 别名或非npm仓库组件 引用即组件标识
+	n, v := pnpmSplitKey(ref, lock.v5())
+	return lock.Packages[n+"@"+v]
+}
+
+// pnpmSplitKey 拆分pnpm-lock中的组件标识
+// v5: /name/1.0.0_peer@1.0.0
+// v6: /name@1.0.0(peer@1.0.0)
+// v9: name@1.0.0(peer@1.0.0)
+func pnpmSplitKey(key string, v5 bool) (name, version string) {
+	key = strings.TrimPrefix(key, "/")
+	if v5 {
+		// v5的peer后缀中scope分隔符为+ 故最后一个/即为名称与版本的分隔
+		if i := strings.LastIndex(key, "/"); i > 0 {
+			return key[:i], key[i+1:]
+		}
+		return key, ""
+	}
+	end := len(key)
+	if i := strings.Index(key, "("); i != -1 {
+		end = i
+	}
+	if i := strings.LastIndex(key[:end], "@"); i > 0 {
+		return key[:i], key[i+1:]
+	}
+	return key, ""
+}
+
+// pnpmTrimPeer 去除版本中的peer后缀
+// v5: 1.0.0_peer@1.0.0
+// v6+: 1.0.0(peer@1.0.0)
+func pnpmTrimPeer(version string, v5 bool) string {
+	sep := "("
+	if v5 {
+		sep = "_"
+	}
+	if i := strings.Index(version, sep); i != -1 {
+		return version[:i]
+	}
+	return version
+}
+
+// ParsePackageJsonWithPnpmLock 借助pnpm-lock.yaml解析package.json
+// importer: package.json所在目录相对pnpm-lock.yaml所在目录的路径
+func ParsePackageJsonWithPnpmLock(pkgjson *PackageJson, pnpmlock *PnpmLock, importer string) *model.DepGraph {
+
+	root := &model.DepGraph{Name: pkgjson.Name, Version: pkgjson.Version, Path: pkgjson.File.Relpath()}
+
+	imp := pnpmlock.Importers[importer]
+	if imp == nil {
+		return root
+	}
+
+	// 记录生产环境可达的组件 lock中未标记dev时以此判断是否为开发组件
+	prod := map[*PnpmPackage]bool{}
+	var q []*PnpmPackage
+	for _, deps := range []map[string]string{imp.Dependencies, imp.OptionalDependencies} {
+		for name, ref := range deps {
+			if pkg := pnpmlock.find(name, ref); pkg != nil && !prod[pkg] {
+				prod[pkg] = true
+				q = append(q, pkg)
+			}
+		}
+	}
+	for len(q) > 0 {
+		pkg := q[0]
+		q = q[1:]
+		for _, deps := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies} {
+			for name, ref := range deps {
+				if sub := pnpmlock.find(name, ref); sub != nil && !prod[sub] {
+					prod[sub] = true
+					q = append(q, sub)
+				}
+			}
+		}
+	}
+
+	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
+		return &model.DepGraph{Name: s[0], Version: s[1]}
+	}).LoadOrStore
+
+	// 同一组件不同peer依赖的锁定记录合并为同一节点
+	seen := map[*model.DepGraph]bool{}
+	node := func(pkg *PnpmPackage) *model.DepGraph {
+		dev := !prod[pkg]
+		if pkg.Dev != nil {
+			dev = *pkg.Dev
+		}
+		dep := _dep(pkg.Name, pkg.Version)
+		if !seen[dep] {
+			seen[dep] = true
+			dep.Develop = dev
+		} else if !dev {
+			dep.Develop = false
+		}
+		return dep
+	}
+
+	visited := map[*PnpmPackage]bool{}
+	q = nil
+	for _, deps := range []map[string]string{imp.Dependencies, imp.OptionalDependencies, imp.DevDependencies} {
+		for name, ref := range deps {
+			if pkg := pnpmlock.find(name, ref); pkg != nil {
+				root.AppendChild(node(pkg))
+				if !visited[pkg] {
+					visited[pkg] = true
+					q = append(q, pkg)
+				}
+			}
+		}
+	}
+
+	for len(q) > 0 {
+		pkg := q[0]
+		q = q[1:]
+		dep := node(pkg)
+		for _, deps := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies} {
+			for name, ref := range deps {
+				sub := pnpmlock.find(name, ref)
+				if sub == nil {
+					continue
+				}
+				dep.AppendChild(node(sub))
+				if !visited[sub] {
+					visited[sub] = true
+					q = append(q, sub)
+				}
+			}
+		}
+	}
+
+	return root
+}
@@ -3,6 +3,7 @@ package javascript
 import (
 	"context"
 	"io"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -20,7 +21,8 @@ func (sca Sca) Language() model.Language {
 func (sca Sca) Filter(relpath string) bool {
 	return filter.JavaScriptPackageJson(relpath) ||
 		filter.JavaScriptPackageLock(relpath) ||
-		filter.JavaScriptYarnLock(relpath)
+		filter.JavaScriptYarnLock(relpath) ||
+		filter.JavaScriptPnpmLock(relpath)
 }
 
 func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
@@ -34,6 +36,8 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 	nodeMap := map[string]*PackageJson{}
 	// map[dirpath]
 	yarnMap := map[string]map[string]*YarnLock{}
+	// map[dirpath]
+	pnpmMap := map[string]*PnpmLock{}
 
 	// 将npm相关文件按上述方案分类
 	for _, f := range files {
@@ -44,6 +48,12 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 			yarnMap[dir] = ParseYarnLock(f)
 		}
 
+		if filter.JavaScriptPnpmLock(f.Relpath()) {
+			if lock := ParsePnpmLock(f); lock != nil {
+				pnpmMap[dir] = lock
+			}
+		}
+
 		if filter.JavaScriptPackageJson(f.Relpath()) {
 			var js *PackageJson
 			f.OpenReader(func(reader io.Reader) {
@@ -79,6 +89,17 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 		}
 	}
 
+	// 记录workspace成员 map[成员目录]workspace根目录
+	members := findWorkspaceMembers(jsonMap)
+	// 记录workspace中的项目名 map[workspace根目录]
+	wsNames := map[string]map[string]bool{}
+	for dir, rootdir := range members {
+		if wsNames[rootdir] == nil {
+			wsNames[rootdir] = map[string]bool{jsonMap[rootdir].Name: true}
+		}
+		wsNames[rootdir][jsonMap[dir].Name] = true
+	}
+
 	// 遍历非node_modules下的package.json
 	for dir, js := range jsonMap {
 
@@ -88,6 +109,12 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 			continue
 		}
 
+		// 尝试从pnpm-lock.yaml获取
+		if lock, importer := findPnpmLock(dir, pnpmMap); lock != nil {
+			call(js.File, ParsePackageJsonWithPnpmLock(js, lock, importer))
+			continue
+		}
+
 		// 尝试从yarn.lock获取
 		if js.File != nil {
 			if yarn, ok := yarnMap[dir]; ok {
@@ -96,6 +123,24 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 			}
 		}
 
+		// 尝试从workspace根目录的lock文件获取
+		if rootdir, ok := members[dir]; ok {
+			member := strings.TrimPrefix(dir, rootdir+"/")
+			if rootdir == "." {
+				member = dir
+			}
+			if lock, ok := lockMap[rootdir]; ok {
+				if root := ParseWorkspaceWithLock(js, lock, member); root != nil {
+					call(js.File, root)
+					continue
+				}
+			}
+			if yarn, ok := yarnMap[rootdir]; ok {
+				call(js.File, ParsePackageJsonWithYarnLock(trimWorkspaceDeps(js, wsNames[rootdir]), yarn))
+				continue
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			return
@@ -107,6 +152,28 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 	}
 }
 
+// findPnpmLock 查找package.json对应的pnpm-lock.yaml
+// 依次向上级目录查找 workspace项目的pnpm-lock.yaml位于workspace根目录
+// dir: package.json所在目录
+// pnpmMap: pnpm-lock.yaml信息 key:pnpm-lock.yaml所在目录
+// importer: package.json所在目录相对pnpm-lock.yaml所在目录的路径
+func findPnpmLock(dir string, pnpmMap map[string]*PnpmLock) (lock *PnpmLock, importer string) {
+	for lockdir := dir; ; lockdir = path.Dir(lockdir) {
+		if lock, ok := pnpmMap[lockdir]; ok {
+			importer = "."
+			if lockdir != dir {
+				importer = strings.TrimPrefix(dir, strings.TrimSuffix(lockdir, "/")+"/")
+			}
+			if _, ok := lock.Importers[importer]; ok {
+				return lock, importer
+			}
+		}
+		if lockdir == path.Dir(lockdir) {
+			return nil, ""
+		}
+	}
+}
+
 var defaultNpmRepo = []common.RepoConfig{
 	{Url: "https://r.cnpmjs.org/"},
 }
@@ -0,0 +1,112 @@
+package javascript
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+)
+
+// workspaces package.json中的workspaces字段 兼容数组及{"packages":[]}格式
+type workspaces []string
+
+func (ws *workspaces) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*ws = list
+		return nil
+	}
+	obj := struct {
+		Packages []string `json:"packages"`
+	}{}
+	// 无法识别的格式忽略该字段
+	if err := json.Unmarshal(data, &obj); err == nil {
+		*ws = obj.Packages
+	}
+	return nil
+}
+
+// findWorkspaceMembers 查找workspace成员
+// jsonMap: 非node_modules下的package.json key:所在目录
+// return: key:成员目录 value:workspace根目录
+func findWorkspaceMembers(jsonMap map[string]*PackageJson) map[string]string {
+	members := map[string]string{}
+	for rootdir, js := range jsonMap {
+		if len(js.Workspaces) == 0 {
+			continue
+		}
+		for dir := range jsonMap {
+			if dir == rootdir {
+				continue
+			}
+			rel := dir
+			if rootdir != "." {
+				var ok bool
+				if rel, ok = strings.CutPrefix(dir, rootdir+"/"); !ok {
+					continue
+				}
+			}
+			if !matchWorkspace(js.Workspaces, rel) {
+				continue
+			}
+			// 嵌套workspace时使用最近的根目录
+			if old, ok := members[dir]; !ok || len(rootdir) > len(old) {
+				members[dir] = rootdir
+			}
+		}
+	}
+	return members
+}
+
+// matchWorkspace 检查目录是否匹配workspace规则 支持* ** 及!排除
+// rel: 相对workspace根目录的路径
+func matchWorkspace(patterns []string, rel string) bool {
+	match := false
+	for _, pattern := range patterns {
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = path.Clean(strings.TrimPrefix(pattern, "!"))
+		if globMatch(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+			match = !exclude
+		}
+	}
+	return match
+}
+
+func globMatch(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if globMatch(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+	return globMatch(pattern[1:], name[1:])
+}
+
+// trimWorkspaceDeps 去除引用workspace中其他项目的依赖
+// names: workspace中的项目名
+func trimWorkspaceDeps(js *PackageJson, names map[string]bool) *PackageJson {
+	trim := func(deps map[string]string) map[string]string {
+		res := map[string]string{}
+		for name, version := range deps {
+			if !names[name] {
+				res[name] = version
+			}
+		}
+		return res
+	}
+	newjs := *js
+	newjs.Dependencies = trim(js.Dependencies)
+	newjs.DevDependencies = trim(js.DevDependencies)
+	newjs.OptionalDependencies = trim(js.OptionalDependencies)
+	return &newjs
+}
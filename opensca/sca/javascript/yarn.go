@@ -1,10 +1,12 @@
 package javascript
 
 import (
+	"io"
 	"strings"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"gopkg.in/yaml.v3"
 )
 
 type YarnLock struct {
@@ -16,6 +18,17 @@ type YarnLock struct {
 // ParseYarnLock 解析yarn.lock文件结构
 func ParseYarnLock(file *model.File) map[string]*YarnLock {
 
+	// yarn2+的yarn.lock为yaml格式 包含__metadata字段
+	berry := false
+	file.ReadLine(func(line string) {
+		if strings.HasPrefix(line, "__metadata:") {
+			berry = true
+		}
+	})
+	if berry {
+		return ParseYarnBerryLock(file)
+	}
+
 	/*
 		  name@version[, name@version]:
 		    version "xxx"
@@ -76,6 +89,90 @@ func ParseYarnLock(file *model.File) map[string]*YarnLock {
 	return lock
 }
 
+// ParseYarnBerryLock 解析yarn2+的yarn.lock文件结构
+// 返回结构与yarn1一致 npm协议的依赖以name:range为key 其他协议保留协议前缀
+func ParseYarnBerryLock(file *model.File) map[string]*YarnLock {
+
+	/*
+		"name@npm:range[, name@npm:range]":
+		  version: xxx
+		  resolution: "name@npm:xxx"
+		  dependencies:
+		    name: "npm:range"
+	*/
+
+	entries := map[string]*struct {
+		Version      string            `yaml:"version"`
+		Resolution   string            `yaml:"resolution"`
+		Dependencies map[string]string `yaml:"dependencies"`
+	}{}
+
+	file.OpenReader(func(reader io.Reader) {
+		if err := yaml.NewDecoder(reader).Decode(&entries); err != nil && err != io.EOF {
+			logs.Warnf("parse file %s fail: %s", file.Relpath(), err)
+		}
+	})
+
+	lock := map[string]*YarnLock{}
+
+	for key, entry := range entries {
+
+		if key == "__metadata" || entry == nil {
+			continue
+		}
+
+		name, protocol := yarnBerryDescriptor(entry.Resolution)
+		if name == "" || yarnWorkspace(protocol) {
+			// 跳过workspace中的项目
+			continue
+		}
+
+		dep := &YarnLock{Name: name, Version: entry.Version, Dependencies: map[string]string{}}
+		for depname, version := range entry.Dependencies {
+			if yarnWorkspace(version) {
+				continue
+			}
+			dep.Dependencies[depname] = yarnBerryRange(version)
+		}
+
+		for tag := range strings.SplitSeq(key, ",") {
+			name, version := yarnBerryDescriptor(strings.TrimSpace(tag))
+			if name == "" {
+				logs.Warnf("parse file %s descriptor: %s fail", file.Relpath(), tag)
+				continue
+			}
+			lock[npmkey(name, yarnBerryRange(version))] = dep
+		}
+	}
+
+	return lock
+}
+
+// yarnBerryDescriptor 拆分yarn2+的依赖描述 例 @scope/name@npm:^1.0.0 => @scope/name npm:^1.0.0
+func yarnBerryDescriptor(descriptor string) (name, version string) {
+	if len(descriptor) == 0 {
+		return
+	}
+	i := strings.Index(descriptor[1:], "@")
+	if i == -1 {
+		return
+	}
+	return descriptor[:i+1], descriptor[i+2:]
+}
+
+// yarnBerryRange 统一依赖版本范围格式 npm协议去除协议前缀(别名除外) 无协议时即为npm协议
+func yarnBerryRange(version string) string {
+	if !strings.HasPrefix(version, "npm:") {
+		return version
+	}
+	rng := strings.TrimPrefix(version, "npm:")
+	// 别名 例 npm:name@^1.0.0
+	if strings.LastIndex(rng, "@") > 0 {
+		return version
+	}
+	return rng
+}
+
 // ParsePackageJsonWithYarnLock 借助yarn.lock文件解析pacakge.json
 func ParsePackageJsonWithYarnLock(pkgjson *PackageJson, yarnlock map[string]*YarnLock) *model.DepGraph {
 
@@ -95,6 +192,9 @@ func ParsePackageJsonWithYarnLock(pkgjson *PackageJson, yarnlock map[string]*Yar
 	}
 
 	for name, version := range pkgjson.Dependencies {
+		if yarnWorkspace(version) {
+			continue
+		}
 		lock := yarnlock[npmkey(name, version)]
 		if lock != nil {
 			root.AppendChild(_dep(lock.Name, lock.Version))
@@ -104,6 +204,9 @@ func ParsePackageJsonWithYarnLock(pkgjson *PackageJson, yarnlock map[string]*Yar
 	}
 
 	for name, version := range pkgjson.DevDependencies {
+		if yarnWorkspace(version) {
+			continue
+		}
 		lock := yarnlock[npmkey(name, version)]
 		if lock != nil {
 			dep := _dep(lock.Name, lock.Version)
@@ -119,3 +222,10 @@ func ParsePackageJsonWithYarnLock(pkgjson *PackageJson, yarnlock map[string]*Yar
 
 	return root
 }
+
+// yarnWorkspace 是否为引用workspace中项目或本地目录的依赖
+func yarnWorkspace(version string) bool {
+	return strings.HasPrefix(version, "workspace:") ||
+		strings.HasPrefix(version, "link:") ||
+		strings.HasPrefix(version, "portal:")
+}
@@ -40,7 +40,12 @@ func ParsePythonWithEnv(ctx context.Context, file *model.File) *model.DepGraph {
 	dir, name := filepath.Split(tempfile)
 
 	if filter.PythonRequirementsTxt(name) {
-		runCmd(ctx, dir, "pipenv", "install", "-r", name, "-i", "https://pypi.tuna.tsinghua.edu.cn/simple")
+		// 优先使用requirements.txt中指定的仓库地址
+		index := "https://pypi.tuna.tsinghua.edu.cn/simple"
+		if urls := ReadRequirementTxt(file).IndexUrls; len(urls) > 0 {
+			index = urls[0]
+		}
+		runCmd(ctx, dir, "pipenv", "install", "-r", name, "-i", index)
 	} else if filter.PythonPipfile(name) {
 		runCmd(ctx, dir, "pipenv", "install", "-i", "https://pypi.tuna.tsinghua.edu.cn/simple")
 	} else {
@@ -0,0 +1,86 @@
+package python
+
+import (
+	"path"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// installedDir 组件元数据所在的安装目录(site-packages)
+// 例 site-packages/requests-2.31.0.dist-info/METADATA | site-packages/six-1.16.0.egg-info
+func installedDir(relpath string) string {
+	relpath = strings.ReplaceAll(relpath, `\`, `/`)
+	if strings.HasSuffix(relpath, ".egg-info") {
+		return path.Dir(relpath)
+	}
+	return path.Dir(path.Dir(relpath))
+}
+
+// ParseInstalled 解析已安装的python组件
+// dir: 安装目录(site-packages)
+// metas: 安装目录下的组件元数据
+func ParseInstalled(dir *model.File, metas []*Metadata) *model.DepGraph {
+
+	root := &model.DepGraph{Path: dir.Relpath()}
+
+	depMap := map[string]*model.DepGraph{}
+	metaMap := map[*model.DepGraph]*Metadata{}
+	deps := []*model.DepGraph{}
+	for _, meta := range metas {
+		if _, ok := depMap[pypiName(meta.Name)]; ok {
+			continue
+		}
+		dep := &model.DepGraph{Name: meta.Name, Version: meta.Version}
+		for _, lic := range meta.Licenses() {
+			dep.AppendLicense(lic)
+		}
+		depMap[pypiName(meta.Name)] = dep
+		metaMap[dep] = meta
+		deps = append(deps, dep)
+	}
+
+	// 记录依赖关系 启用可选功能时的依赖作为该组件的子依赖
+	linked := map[string]bool{}
+	var link func(dep *model.DepGraph, extras []string)
+	link = func(dep *model.DepGraph, extras []string) {
+		key := dep.Name + "[" + strings.Join(extras, ",") + "]"
+		if linked[key] {
+			return
+		}
+		linked[key] = true
+		for _, req := range metaMap[dep].requires(extras) {
+			sub, ok := depMap[pypiName(req.Name)]
+			if !ok || sub == dep {
+				continue
+			}
+			dep.AppendChild(sub)
+			if len(req.Extras) > 0 {
+				link(sub, req.Extras)
+			}
+		}
+	}
+	for _, dep := range deps {
+		link(dep, nil)
+	}
+
+	appendRoots(root, deps)
+
+	// 循环依赖中的组件均有父节点 未被引用时同样作为直接依赖
+	reached := map[*model.DepGraph]bool{}
+	root.ForEachNode(func(p, n *model.DepGraph) bool {
+		reached[n] = true
+		return true
+	})
+	for _, dep := range deps {
+		if !reached[dep] {
+			root.AppendChild(dep)
+			dep.ForEachNode(func(p, n *model.DepGraph) bool {
+				reached[n] = true
+				return true
+			})
+		}
+	}
+
+	return root
+}
@@ -0,0 +1,169 @@
+package python
+
+import (
+	"archive/zip"
+	"bufio"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// Metadata python组件元数据 对应METADATA/PKG-INFO文件
+type Metadata struct {
+	Name    string
+	Version string
+	License string
+	// PEP 639 许可证表达式
+	LicenseExpression string
+	Classifiers       []string
+	// 组件依赖
+	RequiresDist []*Requirement
+}
+
+// ParseMetadata 解析METADATA/PKG-INFO
+// 文件头部为RFC 822格式的键值对 空行后为组件描述
+func ParseMetadata(reader io.Reader) *Metadata {
+
+	meta := &Metadata{}
+
+	set := func(key, value string) {
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(key) {
+		case "name":
+			meta.Name = value
+		case "version":
+			meta.Version = value
+		case "license":
+			meta.License = value
+		case "license-expression":
+			meta.LicenseExpression = value
+		case "classifier":
+			meta.Classifiers = append(meta.Classifiers, value)
+		case "requires-dist":
+			if req := ParseRequirement(value); req != nil {
+				meta.RequiresDist = append(meta.RequiresDist, req)
+			}
+		}
+	}
+
+	var key, value string
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+		// 以空白开头的行为上一字段的续行
+		if line[0] == ' ' || line[0] == '\t' {
+			value += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		if key != "" {
+			set(key, value)
+		}
+		key, value, _ = strings.Cut(line, ":")
+	}
+	if key != "" {
+		set(key, value)
+	}
+
+	if meta.Name == "" {
+		return nil
+	}
+	return meta
+}
+
+// readMetadata 读取METADATA/PKG-INFO文件
+func readMetadata(file *model.File) (meta *Metadata) {
+	file.OpenReader(func(reader io.Reader) {
+		meta = ParseMetadata(reader)
+	})
+	return
+}
+
+// readWheelMetadata 读取wheel包中的METADATA
+func readWheelMetadata(file *model.File) *Metadata {
+	rf, err := zip.OpenReader(file.Abspath())
+	if err != nil {
+		logs.Warnf("open %s fail:%s", file.Relpath(), err)
+		return nil
+	}
+	defer rf.Close()
+	for _, f := range rf.File {
+		dir, name := path.Split(f.Name)
+		if name != "METADATA" || strings.Count(dir, "/") != 1 || !strings.HasSuffix(dir, ".dist-info/") {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			logs.Warnf("open %s fail:%s", file.Relpath(), err)
+			return nil
+		}
+		defer r.Close()
+		return ParseMetadata(r)
+	}
+	return nil
+}
+
+// MetadataMap 本地可获取的组件元数据 key:标准化组件名
+type MetadataMap map[string][]*Metadata
+
+// Add 记录组件元数据
+func (m MetadataMap) Add(meta *Metadata) {
+	if meta != nil {
+		m[pypiName(meta.Name)] = append(m[pypiName(meta.Name)], meta)
+	}
+}
+
+// Find 查找版本一致的组件元数据 版本不一致时依赖关系不可信 返回nil
+func (m MetadataMap) Find(name, version string) *Metadata {
+	for _, meta := range m[pypiName(name)] {
+		if meta.Version == version {
+			return meta
+		}
+	}
+	return nil
+}
+
+// requires 组件在启用指定可选功能时的依赖
+func (meta *Metadata) requires(extras []string) []*Requirement {
+	var reqs []*Requirement
+	for _, req := range meta.RequiresDist {
+		// 仅限定于未启用的可选功能的依赖
+		if extra := req.Extra(); extra != "" && !slices.ContainsFunc(extras, func(e string) bool { return pypiName(e) == extra }) {
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+// Licenses 组件许可证
+// 优先使用License-Expression 其次为License :: 分类 License字段可能为许可证全文 仅在单行时使用
+func (meta *Metadata) Licenses() []string {
+	if meta.LicenseExpression != "" {
+		return []string{meta.LicenseExpression}
+	}
+	var lics []string
+	for _, c := range meta.Classifiers {
+		if !strings.HasPrefix(c, "License ::") {
+			continue
+		}
+		words := strings.Split(c, "::")
+		if lic := strings.TrimSpace(words[len(words)-1]); lic != "" && lic != "OSI Approved" {
+			lics = append(lics, lic)
+		}
+	}
+	if len(lics) > 0 {
+		return lics
+	}
+	if lic := meta.License; lic != "" && lic != "UNKNOWN" && !strings.Contains(lic, "\n") {
+		return []string{lic}
+	}
+	return nil
+}
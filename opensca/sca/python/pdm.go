@@ -0,0 +1,90 @@
+package python
+
+import (
+	"io"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// PdmLock pdm.lock文件结构
+type PdmLock struct {
+	Packages []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		// 启用可选功能时的锁定记录 与组件本身为同一组件
+		Extras []string `toml:"extras"`
+		// 组件所属分组 default以外的分组均为开发分组
+		Groups []string `toml:"groups"`
+		// PEP 508依赖声明
+		Dependencies []string `toml:"dependencies"`
+	} `toml:"package"`
+}
+
+// ParsePdmLock 解析pdm.lock
+// pyfile: 同目录下的pyproject.toml 用于确定直接依赖 不存在时为nil
+func ParsePdmLock(file *model.File, pyfile *model.File) *model.DepGraph {
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	var lock PdmLock
+	file.OpenReader(func(reader io.Reader) {
+		if _, err := toml.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	// 记录组件信息 启用可选功能的记录合并到同一组件
+	depMap := map[string][]*model.DepGraph{}
+	// 记录lock中是否标记了开发组件
+	marked := false
+	deps := []*model.DepGraph{}
+	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
+		dep := &model.DepGraph{Name: s[0], Version: s[1]}
+		depMap[pypiName(s[0])] = append(depMap[pypiName(s[0])], dep)
+		deps = append(deps, dep)
+		return dep
+	}).LoadOrStore
+	for _, pkg := range lock.Packages {
+		dep := _dep(pkg.Name, pkg.Version)
+		if len(pkg.Groups) > 0 {
+			marked = true
+			dep.Develop = !slices.Contains(pkg.Groups, "default")
+		}
+	}
+
+	// 记录依赖关系
+	for _, pkg := range lock.Packages {
+		dep := _dep(pkg.Name, pkg.Version)
+		for _, line := range pkg.Dependencies {
+			req := ParseRequirement(line)
+			if req == nil {
+				continue
+			}
+			// 仅限定于未启用的可选功能的依赖
+			if extra := req.Extra(); extra != "" && !slices.ContainsFunc(pkg.Extras, func(e string) bool { return pypiName(e) == extra }) {
+				continue
+			}
+			for _, sub := range depMap[pypiName(req.Name)] {
+				// 可选功能记录中会引用组件本身
+				if sub != dep {
+					dep.AppendChild(sub)
+				}
+			}
+		}
+	}
+
+	// 不存在pyproject.toml时以没有父节点的组件作为直接依赖
+	if pyfile == nil {
+		appendRoots(root, deps)
+		return root
+	}
+
+	pyproject := readPyproject(pyfile)
+	root.Name, root.Version = pyproject.name()
+	lockDirectDeps(root, pyproject, depMap, marked)
+
+	return root
+}
@@ -0,0 +1,72 @@
+package python
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Requirement PEP 508依赖声明
+// 例 requests[security,socks] (>=2.8.1,<3) ; python_version < "3.8"
+type Requirement struct {
+	// 组件名
+	Name string
+	// 启用的可选功能
+	Extras []string
+	// 版本约束 例 >=2.8.1,<3
+	Version string
+	// 直接引用地址 例 name @ https://xxx
+	Url string
+	// 环境标记 例 python_version < "3.8"
+	Marker string
+	// requirements.txt中--hash指定的文件哈希 例 sha256:xxx
+	Hashes []string
+}
+
+var requirementReg = regexp.MustCompile(`^([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(?:\[([^\]]*)\])?\s*(.*)$`)
+
+// ParseRequirement 解析PEP 508依赖声明 无法解析时返回nil
+func ParseRequirement(line string) *Requirement {
+
+	line = strings.TrimSpace(line)
+
+	req := &Requirement{}
+
+	// 环境标记
+	if i := strings.Index(line, ";"); i != -1 {
+		req.Marker = strings.TrimSpace(line[i+1:])
+		line = strings.TrimSpace(line[:i])
+	}
+
+	match := requirementReg.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	req.Name = match[1]
+	for extra := range strings.SplitSeq(match[2], ",") {
+		if extra = strings.TrimSpace(extra); extra != "" {
+			req.Extras = append(req.Extras, extra)
+		}
+	}
+
+	spec := strings.TrimSpace(match[3])
+	if url, ok := strings.CutPrefix(spec, "@"); ok {
+		req.Url = strings.TrimSpace(url)
+		return req
+	}
+	spec = strings.TrimSuffix(strings.TrimPrefix(spec, "("), ")")
+	req.Version = strings.Join(strings.Fields(spec), "")
+
+	return req
+}
+
+var markerExtraReg = regexp.MustCompile(`extra\s*==\s*['"]([^'"]+)['"]`)
+
+// Extra 环境标记中限定的可选功能 未限定时返回空
+func (req *Requirement) Extra() string {
+	match := markerExtraReg.FindStringSubmatch(req.Marker)
+	if match == nil {
+		return ""
+	}
+	return pypiName(match[1])
+}
@@ -5,35 +5,49 @@ import (
 	"io"
 	"strings"
 
+	"github.com/BurntSushi/toml"
+
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 )
 
-func ParsePipfile(file *model.File) *model.DepGraph {
+// Pipfile Pipfile文件结构
+type Pipfile struct {
+	// value: 版本约束 例 "*" | {version="*",extras=["socks"]}
+	Packages    map[string]any `toml:"packages"`
+	DevPackages map[string]any `toml:"dev-packages"`
+}
 
-	pip := struct {
-		DevPackages map[string]string `toml:"dev-packages"`
-		Packages    map[string]string `toml:"packages"`
-	}{}
+// readPipfile 读取Pipfile
+func readPipfile(file *model.File) *Pipfile {
+	if file == nil {
+		return nil
+	}
+	var pip Pipfile
+	file.OpenReader(func(reader io.Reader) {
+		if _, err := toml.NewDecoder(reader).Decode(&pip); err != nil {
+			logs.Warnf("unmarshal file %s err: %s", file.Relpath(), err)
+		}
+	})
+	return &pip
+}
+
+func ParsePipfile(file *model.File) *model.DepGraph {
 
 	root := &model.DepGraph{Path: file.Relpath()}
 
 	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph { return &model.DepGraph{Name: s[0], Version: s[1]} }).LoadOrStore
 
-	file.OpenReader(func(reader io.Reader) {
-		if err := json.NewDecoder(reader).Decode(&pip); err != nil {
-			logs.Warnf("unmarshal file %s err: %s", file.Relpath(), err)
-		}
-	})
+	pip := readPipfile(file)
 
-	for name, version := range pip.Packages {
-		root.AppendChild(_dep(name, version))
+	for name, v := range pip.Packages {
+		root.AppendChild(_dep(name, poetryVersion(v)))
 	}
-	for name, version := range pip.DevPackages {
-		dep := _dep(name, version)
-		if dep == nil {
+	for name, v := range pip.DevPackages {
+		if _, ok := pip.Packages[name]; ok {
 			continue
 		}
+		dep := _dep(name, poetryVersion(v))
 		dep.Develop = true
 		root.AppendChild(dep)
 	}
@@ -41,58 +55,75 @@ func ParsePipfile(file *model.File) *model.DepGraph {
 	return root
 }
 
-func ParsePipfileLock(file *model.File) *model.DepGraph {
+// PipfileLockPackage Pipfile.lock中锁定的组件
+type PipfileLockPackage struct {
+	Version string   `json:"version"`
+	Extras  []string `json:"extras"`
+}
+
+// ParsePipfileLock 解析Pipfile.lock
+// pipfile: 同目录下的Pipfile 用于确定直接依赖 不存在时为nil
+// metas: 本地可获取的组件元数据 用于确定组件间依赖关系
+func ParsePipfileLock(file, pipfile *model.File, metas MetadataMap) *model.DepGraph {
 
 	lock := struct {
-		Default map[string]struct {
-			Version string `json:"version"`
-		} `json:"default"`
+		Default map[string]PipfileLockPackage `json:"default"`
+		Develop map[string]PipfileLockPackage `json:"develop"`
 	}{}
 
 	root := &model.DepGraph{Path: file.Relpath()}
 
-	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph { return &model.DepGraph{Name: s[0], Version: s[1]} }).LoadOrStore
-
 	file.OpenReader(func(reader io.Reader) {
 		if err := json.NewDecoder(reader).Decode(&lock); err != nil {
 			logs.Warnf("unmarshal file %s err: %s", file.Relpath(), err)
 		}
 	})
 
-	for name, v := range lock.Default {
-		version := strings.TrimPrefix(v.Version, "==")
-		root.AppendChild(_dep(name, version))
-	}
-
-	return root
-}
-
-func ParseRequirementTxt(file *model.File) *model.DepGraph {
-
-	root := &model.DepGraph{Path: file.Relpath()}
-
-	file.ReadLine(func(line string) {
-
-		if strings.HasPrefix(line, `-r`) {
-			return
+	// 记录组件信息 同时出现在default及develop中的组件视为生产组件
+	depMap := map[string]*model.DepGraph{}
+	extraMap := map[*model.DepGraph][]string{}
+	deps := []*model.DepGraph{}
+	record := func(pkgs map[string]PipfileLockPackage, develop bool) {
+		for name, pkg := range pkgs {
+			if dep, ok := depMap[pypiName(name)]; ok {
+				extraMap[dep] = append(extraMap[dep], pkg.Extras...)
+				continue
+			}
+			dep := &model.DepGraph{Name: name, Version: strings.TrimPrefix(pkg.Version, "=="), Develop: develop}
+			depMap[pypiName(name)] = dep
+			extraMap[dep] = pkg.Extras
+			deps = append(deps, dep)
 		}
+	}
+	record(lock.Default, false)
+	record(lock.Develop, true)
 
-		if i := strings.Index(line, "#"); i != -1 {
-			line = line[:i]
+	// 借助组件元数据记录依赖关系
+	for _, dep := range deps {
+		meta := metas.Find(dep.Name, dep.Version)
+		if meta == nil {
+			continue
 		}
-
-		line = strings.TrimSpace(strings.Split(line, ";")[0])
-		if strings.ContainsAny(line, `#$%`) || len(line) == 0 {
-			return
+		for _, req := range meta.requires(extraMap[dep]) {
+			if sub, ok := depMap[pypiName(req.Name)]; ok && sub != dep {
+				dep.AppendChild(sub)
+			}
 		}
+	}
 
-		if i := strings.IndexAny(line, "=!<>~"); i == -1 {
-			root.AppendChild(&model.DepGraph{Name: line})
-		} else {
-			root.AppendChild(&model.DepGraph{Name: line[:i], Version: strings.TrimPrefix(line[i:], "==")})
+	// 存在Pipfile时以Pipfile中声明的组件作为直接依赖
+	if pip := readPipfile(pipfile); pip != nil {
+		for _, pkgs := range []map[string]any{pip.Packages, pip.DevPackages} {
+			for name := range pkgs {
+				if dep, ok := depMap[pypiName(name)]; ok {
+					root.AppendChild(dep)
+				}
+			}
 		}
+	}
 
-	})
+	// 其余没有父节点的组件作为直接依赖 缺少元数据时即为所有组件
+	appendRoots(root, deps)
 
 	return root
 }
@@ -0,0 +1,127 @@
+package python
+
+import (
+	"io"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// PoetryLock poetry.lock文件结构
+type PoetryLock struct {
+	Packages []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		// poetry1.2之前记录的分类 main|dev
+		Category string `toml:"category"`
+		// poetry2记录的分组
+		Groups       []string       `toml:"groups"`
+		Dependencies map[string]any `toml:"dependencies"`
+	} `toml:"package"`
+}
+
+// poetryVersion 获取poetry依赖声明中的版本约束
+// 兼容 "^1.0" | {version="^1.0"} | [{version="^1.0",markers="..."}]
+func poetryVersion(v any) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case map[string]any:
+		if version, ok := value["version"].(string); ok {
+			return version
+		}
+	case []any:
+		if len(value) > 0 {
+			return poetryVersion(value[0])
+		}
+	case []map[string]any:
+		if len(value) > 0 {
+			return poetryVersion(value[0])
+		}
+	}
+	return ""
+}
+
+// poetryExtras 获取poetry依赖声明中启用的可选功能
+func poetryExtras(v any) []string {
+	var extras []string
+	switch value := v.(type) {
+	case map[string]any:
+		if list, ok := value["extras"].([]any); ok {
+			for _, e := range list {
+				if s, ok := e.(string); ok {
+					extras = append(extras, s)
+				}
+			}
+		}
+	case []any:
+		if len(value) > 0 {
+			return poetryExtras(value[0])
+		}
+	case []map[string]any:
+		if len(value) > 0 {
+			return poetryExtras(value[0])
+		}
+	}
+	return extras
+}
+
+// ParsePoetryLock 解析poetry.lock
+// pyfile: 同目录下的pyproject.toml 用于确定直接依赖 不存在时为nil
+func ParsePoetryLock(file *model.File, pyfile *model.File) *model.DepGraph {
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	var lock PoetryLock
+	file.OpenReader(func(reader io.Reader) {
+		if _, err := toml.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	// 记录组件信息 同名组件可能因环境标记锁定多个版本
+	depMap := map[string][]*model.DepGraph{}
+	// 记录lock中是否标记了开发组件
+	marked := false
+	for _, pkg := range lock.Packages {
+		dep := &model.DepGraph{Name: pkg.Name, Version: pkg.Version}
+		if pkg.Category != "" || len(pkg.Groups) > 0 {
+			marked = true
+			dep.Develop = pkg.Category == "dev" || (len(pkg.Groups) > 0 && !slices.Contains(pkg.Groups, "main"))
+		}
+		depMap[pypiName(pkg.Name)] = append(depMap[pypiName(pkg.Name)], dep)
+	}
+
+	// 记录依赖关系
+	for _, pkg := range lock.Packages {
+		for _, dep := range depMap[pypiName(pkg.Name)] {
+			if dep.Version != pkg.Version {
+				continue
+			}
+			for name := range pkg.Dependencies {
+				for _, sub := range depMap[pypiName(name)] {
+					dep.AppendChild(sub)
+				}
+			}
+		}
+	}
+
+	deps := []*model.DepGraph{}
+	for _, pkg := range lock.Packages {
+		deps = append(deps, depMap[pypiName(pkg.Name)]...)
+	}
+
+	// 不存在pyproject.toml时以没有父节点的组件作为直接依赖
+	if pyfile == nil {
+		appendRoots(root, deps)
+		return root
+	}
+
+	pyproject := readPyproject(pyfile)
+	root.Name, root.Version = pyproject.name()
+	lockDirectDeps(root, pyproject, depMap, marked)
+
+	return root
+}
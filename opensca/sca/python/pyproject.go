@@ -0,0 +1,227 @@
+package python
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// Pyproject pyproject.toml文件结构
+type Pyproject struct {
+	// PEP 621
+	Project struct {
+		Name                 string              `toml:"name"`
+		Version              string              `toml:"version"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// PEP 735 依赖分组均视为开发依赖 元素为依赖声明或{include-group="xxx"}
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry struct {
+			Name         string         `toml:"name"`
+			Version      string         `toml:"version"`
+			Dependencies map[string]any `toml:"dependencies"`
+			// poetry1.2之前的开发依赖
+			DevDependencies map[string]any `toml:"dev-dependencies"`
+			// 依赖分组 main以外的分组均视为开发依赖
+			Group map[string]struct {
+				Dependencies map[string]any `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+		Uv struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+		} `toml:"uv"`
+		Pdm struct {
+			DevDependencies map[string][]string `toml:"dev-dependencies"`
+		} `toml:"pdm"`
+	} `toml:"tool"`
+}
+
+// readPyproject 读取pyproject.toml
+func readPyproject(file *model.File) *Pyproject {
+	if file == nil {
+		return nil
+	}
+	var pyproject Pyproject
+	file.OpenReader(func(reader io.Reader) {
+		if _, err := toml.NewDecoder(reader).Decode(&pyproject); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+	return &pyproject
+}
+
+// name 项目名及版本
+func (py *Pyproject) name() (name, version string) {
+	if py.Project.Name != "" {
+		return py.Project.Name, py.Project.Version
+	}
+	return py.Tool.Poetry.Name, py.Tool.Poetry.Version
+}
+
+// devGroup 按照惯例用于开发环境的可选功能/依赖分组
+var devGroup = map[string]bool{
+	"dev":     true,
+	"develop": true,
+	"test":    true,
+	"tests":   true,
+	"testing": true,
+	"lint":    true,
+	"docs":    true,
+	"doc":     true,
+	"typing":  true,
+}
+
+// directDeps pyproject.toml中声明的直接依赖
+// main: 生产依赖 key:标准化组件名
+// dev: 开发依赖 key:标准化组件名
+func (py *Pyproject) directDeps() (main, dev map[string]*Requirement) {
+
+	main, dev = map[string]*Requirement{}, map[string]*Requirement{}
+
+	self, _ := py.name()
+	record := func(m map[string]*Requirement, lines ...string) {
+		for _, line := range lines {
+			req := ParseRequirement(line)
+			// 可选功能中可能引用项目自身 例 all = ["demo[cli,docs]"]
+			if req == nil || (self != "" && pypiName(req.Name) == pypiName(self)) {
+				continue
+			}
+			m[pypiName(req.Name)] = req
+		}
+	}
+
+	// PEP 621
+	record(main, py.Project.Dependencies...)
+	for group, lines := range py.Project.OptionalDependencies {
+		if devGroup[pypiName(group)] {
+			record(dev, lines...)
+		} else {
+			record(main, lines...)
+		}
+	}
+
+	// PEP 735
+	for _, group := range py.DependencyGroups {
+		for _, line := range group {
+			if s, ok := line.(string); ok {
+				record(dev, s)
+			}
+		}
+	}
+
+	// uv & pdm
+	record(dev, py.Tool.Uv.DevDependencies...)
+	for _, lines := range py.Tool.Pdm.DevDependencies {
+		record(dev, lines...)
+	}
+
+	// poetry
+	recordPoetry := func(m map[string]*Requirement, deps map[string]any) {
+		for name, v := range deps {
+			if strings.EqualFold(name, "python") {
+				continue
+			}
+			m[pypiName(name)] = &Requirement{Name: name, Version: poetryVersion(v), Extras: poetryExtras(v)}
+		}
+	}
+	poetry := py.Tool.Poetry
+	recordPoetry(main, poetry.Dependencies)
+	recordPoetry(dev, poetry.DevDependencies)
+	for name, group := range poetry.Group {
+		if name == "main" {
+			recordPoetry(main, group.Dependencies)
+		} else {
+			recordPoetry(dev, group.Dependencies)
+		}
+	}
+
+	// 同时声明为生产及开发依赖时视为生产依赖
+	for name := range main {
+		delete(dev, name)
+	}
+
+	return
+}
+
+var pypiNameReg = regexp.MustCompile(`[-_.]+`)
+
+// pypiName 标准化python组件名(PEP 503)
+func pypiName(name string) string {
+	return strings.ToLower(pypiNameReg.ReplaceAllString(strings.TrimSpace(name), "-"))
+}
+
+// ParsePyproject 解析pyproject.toml
+func ParsePyproject(file *model.File) *model.DepGraph {
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	pyproject := readPyproject(file)
+	root.Name, root.Version = pyproject.name()
+
+	main, dev := pyproject.directDeps()
+	for name, req := range main {
+		root.AppendChild(&model.DepGraph{Name: name, Version: req.Version})
+	}
+	for name, req := range dev {
+		root.AppendChild(&model.DepGraph{Name: name, Version: req.Version, Develop: true})
+	}
+
+	return root
+}
+
+// lockDirectDeps 以pyproject.toml确定lock文件中组件的直接依赖及开发组件
+// depMap: lock中的组件 key:标准化组件名
+// marked: lock中是否已标记开发组件
+func lockDirectDeps(root *model.DepGraph, pyproject *Pyproject, depMap map[string][]*model.DepGraph, marked bool) {
+
+	main, dev := pyproject.directDeps()
+
+	// lock中未标记开发组件时 生产依赖不可达的组件视为开发组件
+	if !marked {
+		prod := map[*model.DepGraph]bool{}
+		for name := range main {
+			for _, dep := range depMap[name] {
+				dep.ForEachNode(func(p, n *model.DepGraph) bool {
+					prod[n] = true
+					return true
+				})
+			}
+		}
+		for _, deps := range depMap {
+			for _, dep := range deps {
+				dep.Develop = !prod[dep]
+			}
+		}
+	}
+
+	direct := func(name string, req *Requirement, develop bool) {
+		if len(depMap[name]) == 0 {
+			root.AppendChild(&model.DepGraph{Name: name, Version: req.Version, Develop: develop})
+			return
+		}
+		for _, dep := range depMap[name] {
+			root.AppendChild(dep)
+		}
+	}
+	for name, req := range main {
+		direct(name, req, false)
+	}
+	for name, req := range dev {
+		direct(name, req, true)
+	}
+}
+
+// appendRoots 没有父节点的组件作为直接依赖
+func appendRoots(root *model.DepGraph, deps []*model.DepGraph) {
+	for _, dep := range deps {
+		if len(dep.Parents) == 0 {
+			root.AppendChild(dep)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package python
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// RequirementTxt requirements.txt解析结果
+type RequirementTxt struct {
+	// 声明的依赖 按声明顺序
+	Requirements []*Requirement
+	// 约束文件中的版本约束 key:标准化组件名
+	Constraints map[string]*Requirement
+	// --index-url/--extra-index-url指定的仓库地址
+	IndexUrls []string
+	// 已读取的文件 key:文件绝对路径
+	Files map[string]bool
+}
+
+var requirementCommentReg = regexp.MustCompile(`(^|\s)#.*$`)
+
+// ReadRequirementTxt 读取requirements.txt 递归读取-r/-c引用的文件
+func ReadRequirementTxt(file *model.File) *RequirementTxt {
+	txt := &RequirementTxt{
+		Constraints: map[string]*Requirement{},
+		Files:       map[string]bool{},
+	}
+	txt.read(file, false)
+	return txt
+}
+
+// read 读取文件
+// constraint: 是否为约束文件
+func (txt *RequirementTxt) read(file *model.File, constraint bool) {
+
+	// 避免循环引用
+	if txt.Files[file.Abspath()] {
+		return
+	}
+	txt.Files[file.Abspath()] = true
+
+	// ref 引用相对当前文件的路径
+	ref := func(name string) *model.File {
+		if strings.Contains(name, "://") {
+			logs.Debugf("skip remote requirement file %s in %s", name, file.Relpath())
+			return nil
+		}
+		if filepath.IsAbs(name) {
+			return model.NewFile(name, name)
+		}
+		return model.NewFile(
+			filepath.Join(filepath.Dir(file.Abspath()), filepath.FromSlash(name)),
+			path.Join(path.Dir(filepath.ToSlash(file.Relpath())), name),
+		)
+	}
+
+	// 续行
+	var buf strings.Builder
+
+	file.ReadLine(func(line string) {
+
+		line = strings.TrimRight(line, "\r")
+		if s, ok := strings.CutSuffix(line, `\`); ok {
+			buf.WriteString(s + " ")
+			return
+		}
+		buf.WriteString(line)
+		line = buf.String()
+		buf.Reset()
+
+		line = strings.TrimSpace(requirementCommentReg.ReplaceAllString(line, ""))
+		if line == "" {
+			return
+		}
+
+		// 选项
+		if strings.HasPrefix(line, "-") {
+			opt, value := requirementOption(line)
+			switch opt {
+			case "-r", "--requirement":
+				if f := ref(value); f != nil {
+					txt.read(f, constraint)
+				}
+			case "-c", "--constraint":
+				if f := ref(value); f != nil {
+					txt.read(f, true)
+				}
+			case "-i", "--index-url", "--extra-index-url":
+				txt.IndexUrls = append(txt.IndexUrls, value)
+			case "-e", "--editable":
+				// 仅记录指定了组件名的vcs依赖 例 git+https://xxx#egg=name
+				if _, egg, ok := strings.Cut(value, "#egg="); ok {
+					txt.add(&Requirement{Name: strings.Split(egg, "&")[0], Url: value}, constraint)
+				}
+			}
+			return
+		}
+
+		// 去除单个依赖的选项并记录哈希 例 --hash=sha256:xxx
+		var hashes []string
+		if i := strings.Index(line, " -"); i != -1 {
+			opts := strings.Fields(line[i:])
+			line = strings.TrimSpace(line[:i])
+			for j, opt := range opts {
+				if hash, ok := strings.CutPrefix(opt, "--hash="); ok {
+					hashes = append(hashes, hash)
+				} else if opt == "--hash" && j+1 < len(opts) {
+					hashes = append(hashes, opts[j+1])
+				}
+			}
+		}
+
+		// 跳过环境变量及本地路径
+		if strings.ContainsAny(line, `$%`) || strings.HasPrefix(line, ".") || strings.HasPrefix(line, "/") || (strings.Contains(line, "://") && !strings.Contains(line, "@")) {
+			return
+		}
+
+		if req := ParseRequirement(line); req != nil {
+			req.Hashes = hashes
+			txt.add(req, constraint)
+		}
+	})
+}
+
+// add 记录依赖
+func (txt *RequirementTxt) add(req *Requirement, constraint bool) {
+	if constraint {
+		txt.Constraints[pypiName(req.Name)] = req
+		return
+	}
+	for _, r := range txt.Requirements {
+		if pypiName(r.Name) == pypiName(req.Name) {
+			return
+		}
+	}
+	txt.Requirements = append(txt.Requirements, req)
+}
+
+// requirementOption 拆分选项及选项值
+// 例 -r a.txt | -ra.txt | --requirement=a.txt | --requirement a.txt
+func requirementOption(line string) (opt, value string) {
+	if strings.HasPrefix(line, "--") {
+		i := strings.IndexAny(line, "= \t")
+		if i == -1 {
+			return line, ""
+		}
+		return line[:i], strings.TrimSpace(strings.TrimLeft(line[i:], "= \t"))
+	}
+	if len(line) < 2 {
+		return line, ""
+	}
+	return line[:2], strings.TrimSpace(line[2:])
+}
+
+// pinVersion 获取版本约束中的确定版本
+// 例 ==1.0 | ===1.0 返回1.0 其余版本约束返回false
+func pinVersion(spec string) (string, bool) {
+	if strings.ContainsAny(spec, ",*") {
+		return "", false
+	}
+	if v, ok := strings.CutPrefix(spec, "==="); ok {
+		return v, true
+	}
+	if v, ok := strings.CutPrefix(spec, "=="); ok {
+		return v, true
+	}
+	return "", false
+}
+
+// Dep 以约束文件确定依赖版本 --hash不影响版本约束
+func (txt *RequirementTxt) Dep(req *Requirement) *model.DepGraph {
+	dep := &model.DepGraph{Name: req.Name}
+	if version, ok := pinVersion(req.Version); ok {
+		dep.Version = version
+		return dep
+	}
+	spec := req.Version
+	if c, ok := txt.Constraints[pypiName(req.Name)]; ok {
+		if version, ok := pinVersion(c.Version); ok {
+			dep.Version = version
+			return dep
+		}
+		if spec == "" {
+			spec = c.Version
+		}
+	}
+	dep.Version = spec
+	dep.VersionRange = spec != ""
+	return dep
+}
+
+// ParseRequirementTxt 解析requirements.txt
+func ParseRequirementTxt(file *model.File) *model.DepGraph {
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	txt := ReadRequirementTxt(file)
+	for _, req := range txt.Requirements {
+		root.AppendChild(txt.Dep(req))
+	}
+
+	return root
+}
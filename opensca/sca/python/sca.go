@@ -3,6 +3,7 @@ package python
 import (
 	"context"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
@@ -20,7 +21,14 @@ func (sca Sca) Filter(relpath string) bool {
 		filter.PythonPipfile(relpath) ||
 		filter.PythonRequirementsIn(relpath) ||
 		filter.PythonRequirementsTxt(relpath) ||
-		filter.PythonSetup(relpath)
+		filter.PythonSetup(relpath) ||
+		filter.PythonPyproject(relpath) ||
+		filter.PythonPoetryLock(relpath) ||
+		filter.PythonUvLock(relpath) ||
+		filter.PythonPdmLock(relpath) ||
+		filter.PythonMetadata(relpath) ||
+		filter.PythonPkgInfo(relpath) ||
+		filter.PythonWheel(relpath)
 }
 
 func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
@@ -29,10 +37,78 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 
 	// 记录存在lock文件的目录
 	lockSet := map[string]bool{}
+	// 记录pyproject.toml map[dir]
+	pyprojectMap := map[string]*model.File{}
+	// 记录Pipfile map[dir]
+	pipfileMap := map[string]*model.File{}
+	// 记录本地可获取的组件元数据
+	metas := MetadataMap{}
+	// 记录存在poetry.lock/uv.lock/pdm.lock的目录
+	pyprojectLockSet := map[string]bool{}
+	// 记录安装目录下的组件元数据 map[dir]
+	installedMap := map[string][]*Metadata{}
+	installedDirs := []*model.File{}
+	// 记录存在项目依赖声明文件的目录
+	manifestSet := map[string]bool{}
 	for _, file := range files {
+		if filter.PythonMetadata(file.Relpath()) || filter.PythonPkgInfo(file.Relpath()) {
+			meta := readMetadata(file)
+			if meta == nil {
+				continue
+			}
+			metas.Add(meta)
+			dir := installedDir(file.Relpath())
+			if _, ok := installedMap[dir]; !ok {
+				abs := filepath.Dir(filepath.Dir(file.Abspath()))
+				if strings.HasSuffix(file.Relpath(), ".egg-info") {
+					abs = filepath.Dir(file.Abspath())
+				}
+				installedDirs = append(installedDirs, model.NewFile(abs, dir))
+			}
+			installedMap[dir] = append(installedMap[dir], meta)
+			continue
+		}
+		if !filter.PythonWheel(file.Relpath()) {
+			manifestSet[path2dir(file.Relpath())] = true
+		}
 		if filter.PythonPipfileLock(file.Relpath()) {
 			lockSet[path2dir(file.Relpath())] = true
 		}
+		if filter.PythonPipfile(file.Relpath()) {
+			pipfileMap[path2dir(file.Relpath())] = file
+		}
+		if filter.PythonWheel(file.Relpath()) {
+			metas.Add(readWheelMetadata(file))
+		}
+		if filter.PythonPyproject(file.Relpath()) {
+			pyprojectMap[path2dir(file.Relpath())] = file
+		}
+		if filter.PythonPoetryLock(file.Relpath()) ||
+			filter.PythonUvLock(file.Relpath()) ||
+			filter.PythonPdmLock(file.Relpath()) {
+			pyprojectLockSet[path2dir(file.Relpath())] = true
+		}
+	}
+
+	// 记录被其他requirements.txt引用的文件 互相引用时保留目录层级较浅的文件
+	includeMap := map[string]map[string]bool{}
+	for _, file := range files {
+		if filter.PythonRequirementsTxt(file.Relpath()) {
+			includeMap[file.Abspath()] = ReadRequirementTxt(file).Files
+		}
+	}
+	depth := func(abs string) int { return strings.Count(filepath.ToSlash(abs), "/") }
+	includeSet := map[string]bool{}
+	for abs, includes := range includeMap {
+		for inc := range includes {
+			if inc == abs {
+				continue
+			}
+			if includeMap[inc][abs] && (depth(inc) < depth(abs) || (depth(inc) == depth(abs) && inc < abs)) {
+				continue
+			}
+			includeSet[inc] = true
+		}
 	}
 
 	// 记录使用pipenv解析过的目录
@@ -65,13 +141,42 @@ func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File,
 				call(file, ParsePipfile(file))
 			}
 		} else if filter.PythonPipfileLock(file.Relpath()) {
-			call(file, ParsePipfileLock(file))
+			call(file, ParsePipfileLock(file, pipfileMap[path2dir(file.Relpath())], metas))
 		} else if filter.PythonRequirementsIn(file.Relpath()) {
 			call(file, ParseRequirementIn(file))
 		} else if filter.PythonRequirementsTxt(file.Relpath()) {
-			call(file, ParseRequirementTxt(file))
+			if !includeSet[file.Abspath()] {
+				call(file, ParseRequirementTxt(file))
+			}
 		} else if filter.PythonSetup(file.Relpath()) {
 			call(file, ParseSetup(file))
+		} else if filter.PythonPoetryLock(file.Relpath()) {
+			call(file, ParsePoetryLock(file, pyprojectMap[path2dir(file.Relpath())]))
+		} else if filter.PythonUvLock(file.Relpath()) {
+			call(file, ParseUvLock(file))
+		} else if filter.PythonPdmLock(file.Relpath()) {
+			call(file, ParsePdmLock(file, pyprojectMap[path2dir(file.Relpath())]))
+		} else if filter.PythonPyproject(file.Relpath()) {
+			if !pyprojectLockSet[path2dir(file.Relpath())] {
+				call(file, ParsePyproject(file))
+			}
+		}
+	}
+
+	// 已安装的组件 位于项目目录下时视为项目的构建产物 仅用于补全依赖关系
+	for _, dir := range installedDirs {
+		inProject := false
+		for d := dir.Relpath(); ; d = path.Dir(d) {
+			if manifestSet[d] {
+				inProject = true
+				break
+			}
+			if d == "." || d == "/" || d == path.Dir(d) {
+				break
+			}
+		}
+		if !inProject {
+			call(dir, ParseInstalled(dir, installedMap[dir.Relpath()]))
 		}
 	}
 }
@@ -0,0 +1,155 @@
+package python
+
+import (
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// UvLock uv.lock文件结构
+type UvLock struct {
+	Packages []*UvPackage `toml:"package"`
+}
+
+// UvPackage uv锁定的组件
+type UvPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	// 组件来源 项目自身为{editable="."}或{virtual="."}
+	Source struct {
+		Editable string `toml:"editable"`
+		Virtual  string `toml:"virtual"`
+	} `toml:"source"`
+	Dependencies []UvDependency `toml:"dependencies"`
+	// 可选功能的依赖 key:可选功能名
+	OptionalDependencies map[string][]UvDependency `toml:"optional-dependencies"`
+	// 开发依赖分组 key:分组名
+	DevDependencies map[string][]UvDependency `toml:"dev-dependencies"`
+}
+
+// UvDependency uv组件依赖
+type UvDependency struct {
+	Name string `toml:"name"`
+	// 同名组件锁定多个版本时记录引用的版本
+	Version string `toml:"version"`
+	// 启用的可选功能
+	Extra  []string `toml:"extra"`
+	Marker string   `toml:"marker"`
+}
+
+// root 是否为项目自身
+func (pkg *UvPackage) root() bool {
+	return pkg.Source.Editable == "." || pkg.Source.Virtual == "."
+}
+
+// ParseUvLock 解析uv.lock
+func ParseUvLock(file *model.File) *model.DepGraph {
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	var lock UvLock
+	file.OpenReader(func(reader io.Reader) {
+		if _, err := toml.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	// 项目自身
+	var project *UvPackage
+	// 记录组件信息 同名组件可能因环境标记锁定多个版本
+	depMap := map[string][]*model.DepGraph{}
+	pkgMap := map[*model.DepGraph]*UvPackage{}
+	deps := []*model.DepGraph{}
+	for _, pkg := range lock.Packages {
+		if project == nil && pkg.root() {
+			project = pkg
+			root.Name, root.Version = pkg.Name, pkg.Version
+			continue
+		}
+		dep := &model.DepGraph{Name: pkg.Name, Version: pkg.Version}
+		depMap[pypiName(pkg.Name)] = append(depMap[pypiName(pkg.Name)], dep)
+		pkgMap[dep] = pkg
+		deps = append(deps, dep)
+	}
+
+	// find 查找依赖引用的组件
+	find := func(d UvDependency) []*model.DepGraph {
+		var res []*model.DepGraph
+		for _, dep := range depMap[pypiName(d.Name)] {
+			if d.Version == "" || d.Version == dep.Version {
+				res = append(res, dep)
+			}
+		}
+		return res
+	}
+
+	// linked 已添加的可选功能依赖 避免可选功能循环引用时无限递归
+	type extraKey struct {
+		dep   *model.DepGraph
+		extra string
+	}
+	linked := map[extraKey]bool{}
+
+	// link 添加依赖关系 启用的可选功能的依赖作为引用组件的子依赖
+	var link func(parent *model.DepGraph, ds []UvDependency)
+	link = func(parent *model.DepGraph, ds []UvDependency) {
+		for _, d := range ds {
+			for _, sub := range find(d) {
+				parent.AppendChild(sub)
+				for _, extra := range d.Extra {
+					key := extraKey{sub, extra}
+					if linked[key] {
+						continue
+					}
+					linked[key] = true
+					link(sub, pkgMap[sub].OptionalDependencies[extra])
+				}
+			}
+		}
+	}
+	for _, dep := range deps {
+		link(dep, pkgMap[dep].Dependencies)
+	}
+
+	if project == nil {
+		appendRoots(root, deps)
+		return root
+	}
+
+	// 项目直接依赖 开发分组及开发相关的可选功能视为开发依赖
+	main, dev := &model.DepGraph{}, &model.DepGraph{}
+	link(main, project.Dependencies)
+	for extra, ds := range project.OptionalDependencies {
+		if devGroup[pypiName(extra)] {
+			link(dev, ds)
+		} else {
+			link(main, ds)
+		}
+	}
+	for _, ds := range project.DevDependencies {
+		link(dev, ds)
+	}
+
+	// 生产依赖不可达的组件视为开发组件
+	prod := map[*model.DepGraph]bool{}
+	for _, dep := range main.Children {
+		dep.ForEachNode(func(p, n *model.DepGraph) bool {
+			prod[n] = true
+			return true
+		})
+	}
+	for _, dep := range deps {
+		dep.Develop = !prod[dep]
+	}
+
+	for _, tmp := range []*model.DepGraph{main, dev} {
+		for _, dep := range append([]*model.DepGraph{}, tmp.Children...) {
+			tmp.RemoveChild(dep)
+			root.AppendChild(dep)
+		}
+	}
+
+	return root
+}
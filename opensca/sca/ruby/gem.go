@@ -1,64 +1,166 @@
 package ruby
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 )
 
-// ParseGemfileLock 解析Gemfile.lock文件
-func ParseGemfileLock(file *model.File) *model.DepGraph {
+// GemfileLock Gemfile.lock文件结构
+type GemfileLock struct {
+	Specs []*GemSpec
+	// DEPENDENCIES中记录的直接依赖
+	Dependencies []string
+}
 
-	// map[name]dep
-	depMap := map[string]*model.DepGraph{}
+// GemSpec Gemfile.lock中specs下的gem
+type GemSpec struct {
+	Name    string
+	Version string
+	// 所在的块 GEM | GIT | PATH | PLUGIN SOURCE
+	Source string
+	// 来源地址 GEM为仓库地址 GIT为仓库地址 PATH为本地路径
+	Remote string
+	// 依赖的gem名
+	Deps []string
+}
+
+// gemSource Gemfile.lock中的块对应的组件来源
+var gemSource = map[string]string{
+	"GEM":  model.SourceRegistry,
+	"GIT":  model.SourceGit,
+	"PATH": model.SourcePath,
+}
+
+// ReadGemfileLock 按块读取Gemfile.lock
+func ReadGemfileLock(file *model.File) *GemfileLock {
+
+	lock := &GemfileLock{}
 
-	space4 := "    "
-	space6 := "      "
 	parseLine := func(line string) (name, version string) {
 		line = strings.TrimSpace(line)
-		i := strings.Index(line, " ")
-		if i == -1 {
-			name = line
-			return
-		}
-		name = line[:i]
-		version = strings.Trim(line[i+1:], "()")
+		name, version, _ = strings.Cut(line, " ")
+		version = strings.Trim(version, "()")
 		return
 	}
 
-	// 第一次记录依赖信息
+	// 当前所在的块
+	var section, remote string
+	var cur *GemSpec
 	file.ReadLine(func(line string) {
-		if !strings.HasPrefix(line, space4) || strings.HasPrefix(line, space6) {
+
+		if strings.TrimSpace(line) == "" {
 			return
 		}
-		name, version := parseLine(line)
-		if version == "" {
+
+		// 块名 例 GEM | PLATFORMS | DEPENDENCIES
+		if !strings.HasPrefix(line, " ") {
+			section = strings.TrimSpace(line)
+			remote = ""
+			cur = nil
 			return
 		}
-		depMap[name] = &model.DepGraph{
-			Name:    name,
-			Version: version,
+
+		switch section {
+		case "GEM", "GIT", "PATH", "PLUGIN SOURCE":
+			switch {
+			case strings.HasPrefix(line, "      "):
+				if cur != nil {
+					name, _ := parseLine(line)
+					cur.Deps = append(cur.Deps, name)
+				}
+			case strings.HasPrefix(line, "    "):
+				name, version := parseLine(line)
+				// 去除平台后缀 例 1.15.4-x86_64-linux
+				version, _, _ = strings.Cut(version, "-")
+				cur = &GemSpec{Name: name, Version: version, Source: section, Remote: remote}
+				lock.Specs = append(lock.Specs, cur)
+			default:
+				if s, ok := strings.CutPrefix(strings.TrimSpace(line), "remote:"); ok {
+					remote = strings.TrimSpace(s)
+				}
+			}
+		case "DEPENDENCIES":
+			if strings.HasPrefix(line, "    ") {
+				return
+			}
+			name, _ := parseLine(line)
+			// 非默认来源的gem以!结尾
+			lock.Dependencies = append(lock.Dependencies, strings.TrimSuffix(name, "!"))
 		}
 	})
 
-	// 第二次记录依赖关系
-	var last string
-	file.ReadLine(func(line string) {
-		if strings.HasPrefix(line, space6) {
-			name, _ := parseLine(line)
-			depMap[last].AppendChild(depMap[name])
-			return
+	return lock
+}
+
+// ParseGemfileLock 解析Gemfile.lock文件
+// gemfile: Gemfile及gemspec中声明的依赖 用于识别开发依赖 不存在时为nil
+func ParseGemfileLock(file *model.File, gemfile *Gemfile) *model.DepGraph {
+
+	lock := ReadGemfileLock(file)
+
+	// map[name]dep 不同平台的同一gem仅记录一次
+	depMap := map[string]*model.DepGraph{}
+	for _, spec := range lock.Specs {
+		if _, ok := depMap[spec.Name]; ok {
+			continue
 		}
-		if strings.HasPrefix(line, space4) {
-			last, _ = parseLine(line)
-			return
+		depMap[spec.Name] = &model.DepGraph{
+			Name:    spec.Name,
+			Version: spec.Version,
+			Source:  gemSource[spec.Source],
 		}
-	})
+	}
+
+	// 记录依赖关系
+	for _, spec := range lock.Specs {
+		for _, name := range spec.Deps {
+			depMap[spec.Name].AppendChild(depMap[name])
+		}
+	}
 
 	root := &model.DepGraph{Path: file.Relpath()}
-	for _, d := range depMap {
-		if len(d.Parents) == 0 {
-			root.AppendChild(d)
+
+	// gemspec声明的项目自身作为根组件 其依赖作为直接依赖
+	var project *model.DepGraph
+	if gemfile != nil {
+		for _, spec := range lock.Specs {
+			if spec.Source == "PATH" && slices.Contains(gemfile.Projects, spec.Name) {
+				project = depMap[spec.Name]
+				root.Name, root.Version = project.Name, project.Version
+				break
+			}
+		}
+	}
+
+	// DEPENDENCIES中的gem为直接依赖
+	for _, name := range lock.Dependencies {
+		dep, ok := depMap[name]
+		if !ok {
+			continue
+		}
+		if dep == project {
+			for _, sub := range append([]*model.DepGraph{}, dep.Children...) {
+				dep.RemoveChild(sub)
+				if gemfile.Develop(sub.Name) {
+					sub.Develop = true
+				}
+				root.AppendChild(sub)
+			}
+			continue
+		}
+		if gemfile != nil && gemfile.Develop(name) {
+			dep.Develop = true
+		}
+		root.AppendChild(dep)
+	}
+
+	if len(root.Children) == 0 {
+		for _, d := range depMap {
+			if len(d.Parents) == 0 {
+				root.AppendChild(d)
+			}
 		}
 	}
 
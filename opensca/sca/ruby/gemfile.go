@@ -0,0 +1,170 @@
+package ruby
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// Gemfile Gemfile及gemspec中声明的依赖
+type Gemfile struct {
+	Gems []*GemDeclare
+	// gemspec声明的项目自身的gem名
+	Projects []string
+}
+
+// GemDeclare 声明的gem
+type GemDeclare struct {
+	Name string
+	// 版本约束 例 ~> 3.0
+	Requirement string
+	// 所属分组 未指定时为default
+	Groups []string
+}
+
+var (
+	gemRe     = regexp.MustCompile(`^gem\s*\(?\s*['"]([^'"]+)['"]((?:\s*,\s*['"][^'"]*['"])*)(.*)$`)
+	groupRe   = regexp.MustCompile(`^group\s*\(?\s*(.+?)\)?\s+do\b`)
+	optionRe  = regexp.MustCompile(`(?:group|groups)\s*(?::|=>)\s*(\[[^\]]*\]|:\w+|['"]\w+['"])`)
+	gemspecRe = regexp.MustCompile(`^gemspec\b(.*)$`)
+	pathRe    = regexp.MustCompile(`path\s*(?::|=>)\s*['"]([^'"]+)['"]`)
+	symbolRe  = regexp.MustCompile(`(?:^|[\s,\[(])(?::(\w+)|['"](\w+)['"])`)
+	specRe    = regexp.MustCompile(`\.name\s*=\s*['"]([^'"]+)['"]`)
+	specDepRe = regexp.MustCompile(`\.add_(development_|runtime_)?dependency\s*\(?\s*['"]([^'"]+)['"]((?:\s*,\s*['"][^'"]*['"])*)`)
+	quotedRe  = regexp.MustCompile(`['"]([^'"]*)['"]`)
+)
+
+// devGroups 开发环境使用的分组
+var devGroups = map[string]bool{"development": true, "test": true}
+
+// Develop gem是否仅在开发分组中声明
+func (gemfile *Gemfile) Develop(name string) bool {
+	found := false
+	for _, gem := range gemfile.Gems {
+		if gem.Name != name {
+			continue
+		}
+		found = true
+		for _, g := range gem.Groups {
+			if !devGroups[g] {
+				return false
+			}
+		}
+	}
+	return found
+}
+
+// symbols 解析分组列表 例 :development, :test | [:development, "test"]
+func symbols(s string) []string {
+	var res []string
+	for _, m := range symbolRe.FindAllStringSubmatch(s, -1) {
+		res = append(res, m[1]+m[2])
+	}
+	return res
+}
+
+// requirement 合并版本约束 例 "~> 1.0", ">= 1.0.2"
+func requirement(s string) string {
+	var reqs []string
+	for _, m := range quotedRe.FindAllStringSubmatch(s, -1) {
+		reqs = append(reqs, m[1])
+	}
+	return strings.Join(reqs, ", ")
+}
+
+// ReadGemfile 读取Gemfile/gems.rb
+// gemspecs: 项目中的gemspec文件 key:所在目录
+func ReadGemfile(file *model.File, gemspecs map[string][]*model.File) *Gemfile {
+
+	gemfile := &Gemfile{}
+
+	// 当前所在的块 非group块为nil
+	var blocks [][]string
+	groups := func() []string {
+		for i := len(blocks) - 1; i >= 0; i-- {
+			if blocks[i] != nil {
+				return blocks[i]
+			}
+		}
+		return []string{"default"}
+	}
+
+	file.ReadLine(func(line string) {
+
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, "#"); i != -1 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			return
+		}
+
+		if line == "end" {
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+			return
+		}
+
+		if m := groupRe.FindStringSubmatch(line); m != nil {
+			blocks = append(blocks, symbols(m[1]))
+			return
+		}
+
+		if m := gemRe.FindStringSubmatch(line); m != nil {
+			gem := &GemDeclare{Name: m[1], Requirement: requirement(m[2]), Groups: groups()}
+			if o := optionRe.FindStringSubmatch(m[3]); o != nil {
+				gem.Groups = symbols(o[1])
+			}
+			gemfile.Gems = append(gemfile.Gems, gem)
+			return
+		}
+
+		// 引用gemspec中声明的依赖 开发依赖属于development分组
+		if m := gemspecRe.FindStringSubmatch(line); m != nil {
+			dir := filepath.Dir(file.Relpath())
+			if p := pathRe.FindStringSubmatch(m[1]); p != nil {
+				dir = filepath.Join(dir, filepath.FromSlash(p[1]))
+			}
+			for _, spec := range gemspecs[dir] {
+				gemspec := ReadGemspec(spec)
+				gemfile.Gems = append(gemfile.Gems, gemspec.Gems...)
+				gemfile.Projects = append(gemfile.Projects, gemspec.Projects...)
+			}
+			return
+		}
+
+		// 其他块 例 platforms :jruby do | source "xxx" do | if xxx
+		if strings.HasSuffix(line, " do") || strings.Contains(line, " do |") ||
+			strings.HasPrefix(line, "if ") || strings.HasPrefix(line, "unless ") || strings.HasPrefix(line, "case ") {
+			blocks = append(blocks, nil)
+		}
+	})
+
+	return gemfile
+}
+
+// ReadGemspec 读取gemspec中声明的gem名及依赖 未声明gem名时使用文件名
+func ReadGemspec(file *model.File) *Gemfile {
+	gemfile := &Gemfile{}
+	name := strings.TrimSuffix(filepath.Base(file.Relpath()), ".gemspec")
+	file.ReadLine(func(line string) {
+		if m := specRe.FindStringSubmatch(line); m != nil {
+			name = m[1]
+			return
+		}
+		m := specDepRe.FindStringSubmatch(line)
+		if m == nil {
+			return
+		}
+		group := "default"
+		if m[1] == "development_" {
+			group = "development"
+		}
+		gemfile.Gems = append(gemfile.Gems, &GemDeclare{Name: m[2], Requirement: requirement(m[3]), Groups: []string{group}})
+	})
+	gemfile.Projects = []string{name}
+	return gemfile
+}
@@ -2,6 +2,7 @@ package ruby
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
@@ -14,13 +15,31 @@ func (sca Sca) Language() model.Language {
 }
 
 func (sca Sca) Filter(relpath string) bool {
-	return filter.RubyGemfileLock(relpath)
+	return filter.RubyGemfileLock(relpath) || filter.RubyGemfile(relpath) || filter.RubyGemspec(relpath)
 }
 
 func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// map[dir]*File
+	gemfiles := map[string]*model.File{}
+	gemspecs := map[string][]*model.File{}
+	for _, file := range files {
+		dir := filepath.Dir(file.Relpath())
+		if filter.RubyGemfile(file.Relpath()) {
+			gemfiles[dir] = file
+		}
+		if filter.RubyGemspec(file.Relpath()) {
+			gemspecs[dir] = append(gemspecs[dir], file)
+		}
+	}
+
 	for _, file := range files {
 		if filter.RubyGemfileLock(file.Relpath()) {
-			call(file, ParseGemfileLock(file))
+			var gemfile *Gemfile
+			if f, ok := gemfiles[filepath.Dir(file.Relpath())]; ok {
+				gemfile = ReadGemfile(f, gemspecs)
+			}
+			call(file, ParseGemfileLock(file, gemfile))
 		}
 	}
 }
@@ -0,0 +1,87 @@
+package rust
+
+import (
+	"compress/zlib"
+	"debug/elf"
+	"encoding/json"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// AuditableInfo cargo-auditable写入.dep-v0段的依赖信息
+type AuditableInfo struct {
+	Packages []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Source  string `json:"source"`
+		// runtime | build 默认为runtime
+		Kind string `json:"kind"`
+		// 依赖的组件在Packages中的下标
+		Dependencies []int `json:"dependencies"`
+		Root         bool  `json:"root"`
+	} `json:"packages"`
+}
+
+// ParseAuditableBinary 解析cargo-auditable构建的ELF文件
+// 非ELF或不含.dep-v0段时返回nil
+func ParseAuditableBinary(file *model.File) *model.DepGraph {
+
+	f, err := elf.Open(file.Abspath())
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	section := f.Section(".dep-v0")
+	if section == nil {
+		return nil
+	}
+
+	// 段内容为zlib压缩的json
+	r, err := zlib.NewReader(section.Open())
+	if err != nil {
+		logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		return nil
+	}
+	defer r.Close()
+
+	var info AuditableInfo
+	if err := json.NewDecoder(r).Decode(&info); err != nil {
+		logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		return nil
+	}
+
+	deps := make([]*model.DepGraph, len(info.Packages))
+	for i, p := range info.Packages {
+		deps[i] = &model.DepGraph{
+			Name:    p.Name,
+			Version: p.Version,
+			// 仅构建时使用的组件
+			Develop: p.Kind == "build",
+		}
+	}
+
+	root := &model.DepGraph{Path: file.Relpath()}
+	for i, p := range info.Packages {
+		for _, j := range p.Dependencies {
+			if j >= 0 && j < len(deps) && j != i {
+				deps[i].AppendChild(deps[j])
+			}
+		}
+		if p.Root {
+			root.AppendChild(deps[i])
+		}
+	}
+
+	// 未标记根组件时以没有父组件的组件作为根组件
+	if len(root.Children) == 0 {
+		for _, dep := range deps {
+			if len(dep.Parents) == 0 {
+				root.AppendChild(dep)
+			}
+		}
+	}
+
+	return root
+}
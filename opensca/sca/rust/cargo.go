@@ -11,12 +11,16 @@ import (
 )
 
 // ParseCargoLock 解析Cargo.lock文件
-func ParseCargoLock(file *model.File) *model.DepGraph {
+// ws: 工作区根目录或单个crate的Cargo.toml 用于继承工作区中声明的依赖 不存在时为nil
+// crates: 工作区中的crate 用于识别本地crate及开发依赖 不存在时为nil
+func ParseCargoLock(file *model.File, ws *CargoToml, crates []*CargoToml) *model.DepGraph {
 
 	cargo := struct {
 		Pkgs []struct {
-			Name         string   `toml:"name"`
-			Version      string   `toml:"version"`
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+			// 组件来源 例 registry+https://github.com/rust-lang/crates.io-index | git+https://xxx#rev 本地crate为空
+			Source       string   `toml:"source"`
 			Dependencies []string `toml:"dependencies"`
 		} `toml:"package"`
 	}{}
@@ -28,6 +32,14 @@ func ParseCargoLock(file *model.File) *model.DepGraph {
 		}
 	})
 
+	// 工作区中的crate key:crate名
+	local := map[string]*CargoToml{}
+	for _, c := range crates {
+		if c.Package.Name != "" {
+			local[c.Package.Name] = c
+		}
+	}
+
 	// 记录组件信息
 	depMap := map[string]*model.DepGraph{}
 	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
@@ -37,29 +49,120 @@ func ParseCargoLock(file *model.File) *model.DepGraph {
 		}
 	}).LoadOrStore
 	for _, c := range cargo.Pkgs {
-		depMap[c.Name] = _dep(c.Name, c.Version)
+		dep := _dep(c.Name, c.Version)
+		dep.Source = cargoSource(c.Source)
+		depMap[c.Name] = dep
 	}
 
-	// 记录依赖关系
+	// 记录依赖关系 v1格式为 name version (source)
 	for _, c := range cargo.Pkgs {
 		dep := _dep(c.Name, c.Version)
 		for _, dependency := range c.Dependencies {
-			i := strings.Index(dependency, " ")
-			if i != -1 {
-				name, version := dependency[:i], dependency[i+1:]
-				dep.AppendChild(_dep(name, version))
+			words := strings.Fields(dependency)
+			if len(words) == 0 {
+				continue
+			}
+			if len(words) > 1 {
+				dep.AppendChild(_dep(words[0], words[1]))
 			} else {
-				dep.AppendChild(depMap[dependency])
+				dep.AppendChild(depMap[words[0]])
 			}
 		}
 	}
 
 	root := &model.DepGraph{Path: file.Relpath()}
-	for _, dep := range depMap {
-		if len(dep.Parents) == 0 {
-			root.AppendChild(dep)
+
+	// 以工作区中的crate作为根组件
+	if len(local) > 0 {
+
+		// 工作区中的crate key:crate组件
+		members := map[*model.DepGraph]*CargoToml{}
+		for _, c := range cargo.Pkgs {
+			crate, ok := local[c.Name]
+			if !ok || c.Source != "" {
+				continue
+			}
+			node := _dep(c.Name, c.Version)
+			node.Path = crate.File.Relpath()
+			node.Source = ""
+			members[node] = crate
+			root.AppendChild(node)
+		}
+
+		// devOnly crate中仅声明为dev-dependencies或build-dependencies的组件 key:Cargo.lock中的组件名
+		devOnly := func(crate *CargoToml) map[string]bool {
+			dev := map[string]bool{}
+			deps := crate.deps(ws)
+			for _, d := range deps {
+				if d.Develop {
+					dev[d.Name] = true
+				}
+			}
+			for _, d := range deps {
+				if !d.Develop {
+					delete(dev, d.Name)
+				}
+			}
+			return dev
+		}
+
+		// 任一crate的生产依赖可达的组件 其他crate的依赖由其自身判断
+		prod := map[*model.DepGraph]bool{}
+		for _, node := range root.Children {
+			dev := devOnly(members[node])
+			for _, sub := range node.Children {
+				if dev[sub.Name] {
+					continue
+				}
+				sub.ForEachNode(func(p, n *model.DepGraph) bool {
+					if _, ok := members[n]; ok {
+						return false
+					}
+					prod[n] = true
+					return true
+				})
+			}
+		}
+
+		// 仅被crate作为开发依赖引用的组件视为开发依赖
+		for _, node := range root.Children {
+			dev := devOnly(members[node])
+			for _, sub := range node.Children {
+				if _, ok := members[sub]; !ok && dev[sub.Name] && !prod[sub] {
+					sub.Develop = true
+				}
+			}
+		}
+
+		// 工作区外的本地路径依赖 版本号留空
+		for _, c := range cargo.Pkgs {
+			if _, ok := local[c.Name]; !ok && c.Source == "" {
+				_dep(c.Name, c.Version).Version = ""
+			}
+		}
+	}
+
+	if len(root.Children) == 0 {
+		for _, dep := range depMap {
+			if len(dep.Parents) == 0 {
+				root.AppendChild(dep)
+			}
 		}
 	}
 
 	return root
 }
+
+// cargoSource Cargo.lock中的组件来源类型
+// 例 registry+https://github.com/rust-lang/crates.io-index | sparse+https://xxx | git+https://xxx#rev 本地路径为空
+func cargoSource(source string) string {
+	switch {
+	case strings.HasPrefix(source, "registry+"), strings.HasPrefix(source, "sparse+"):
+		return model.SourceRegistry
+	case strings.HasPrefix(source, "git+"):
+		return model.SourceGit
+	case source == "":
+		return model.SourcePath
+	}
+	return ""
+}
@@ -0,0 +1,269 @@
+package rust
+
+import (
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// CargoToml Cargo.toml文件结构
+type CargoToml struct {
+	File    *model.File `toml:"-"`
+	Package struct {
+		Name string `toml:"name"`
+		// 版本号 例 "0.1.0" | { workspace = true }
+		Version any `toml:"version"`
+	} `toml:"package"`
+	cargoDeps
+	Target    map[string]cargoDeps `toml:"target"`
+	Workspace struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+		Package struct {
+			Version string `toml:"version"`
+		} `toml:"package"`
+		Dependencies map[string]any `toml:"dependencies"`
+	} `toml:"workspace"`
+}
+
+type cargoDeps struct {
+	Dependencies      map[string]any `toml:"dependencies"`
+	DevDependencies   map[string]any `toml:"dev-dependencies"`
+	BuildDependencies map[string]any `toml:"build-dependencies"`
+}
+
+// CargoDep Cargo.toml中声明的依赖
+type CargoDep struct {
+	// crate名 重命名时为package字段
+	Name string
+	// 版本约束 例 1.0 | =1.2.3 | >=1, <2
+	Version string
+	// 本地路径
+	Path string
+	// git仓库地址
+	Git string
+	// 是否为dev-dependencies或build-dependencies
+	Develop bool
+}
+
+// readCargoToml 读取Cargo.toml
+func readCargoToml(file *model.File) *CargoToml {
+	cargo := &CargoToml{}
+	file.OpenReader(func(reader io.Reader) {
+		if _, err := toml.NewDecoder(reader).Decode(cargo); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+	cargo.File = file
+	return cargo
+}
+
+// IsWorkspace 是否为工作区根目录
+func (cargo *CargoToml) IsWorkspace() bool {
+	return len(cargo.Workspace.Members) > 0
+}
+
+// version 当前crate的版本号 ws:所在工作区 不存在时为nil
+func (cargo *CargoToml) version(ws *CargoToml) string {
+	switch v := cargo.Package.Version.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if ws != nil && v["workspace"] == true {
+			return ws.Workspace.Package.Version
+		}
+	}
+	return ""
+}
+
+// deps 当前crate声明的依赖 ws:所在工作区 不存在时为nil
+func (cargo *CargoToml) deps(ws *CargoToml) []*CargoDep {
+
+	var deps []*CargoDep
+
+	add := func(m map[string]any, develop bool) {
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			dep := parseCargoDep(name, m[name])
+			// 继承工作区中声明的依赖 例 serde = { workspace = true }
+			if spec, ok := m[name].(map[string]any); ok && spec["workspace"] == true && ws != nil {
+				if wsSpec, ok := ws.Workspace.Dependencies[name]; ok {
+					dep = parseCargoDep(name, wsSpec)
+					// 工作区中的本地路径相对于工作区根目录
+					if dep.Path != "" {
+						dep.Path = filepath.Join(filepath.Dir(ws.File.Relpath()), filepath.FromSlash(dep.Path))
+					}
+				}
+			} else if dep.Path != "" {
+				dep.Path = filepath.Join(filepath.Dir(cargo.File.Relpath()), filepath.FromSlash(dep.Path))
+			}
+			dep.Develop = develop
+			deps = append(deps, dep)
+		}
+	}
+
+	targets := []cargoDeps{cargo.cargoDeps}
+	keys := make([]string, 0, len(cargo.Target))
+	for k := range cargo.Target {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		targets = append(targets, cargo.Target[k])
+	}
+
+	for _, t := range targets {
+		add(t.Dependencies, false)
+		add(t.DevDependencies, true)
+		add(t.BuildDependencies, true)
+	}
+
+	return deps
+}
+
+// parseCargoDep 解析依赖声明 例 serde = "1.0" | serde = { version = "1.0", package = "serde" }
+func parseCargoDep(name string, spec any) *CargoDep {
+	dep := &CargoDep{Name: name}
+	switch s := spec.(type) {
+	case string:
+		dep.Version = s
+	case map[string]any:
+		if v, ok := s["package"].(string); ok {
+			dep.Name = v
+		}
+		if v, ok := s["version"].(string); ok {
+			dep.Version = v
+		}
+		if v, ok := s["path"].(string); ok {
+			dep.Path = v
+		}
+		if v, ok := s["git"].(string); ok {
+			dep.Git = v
+		}
+	}
+	return dep
+}
+
+// exactVersion 版本约束为确定版本时返回该版本 例 =1.2.3
+func exactVersion(version string) (string, bool) {
+	v := strings.TrimSpace(version)
+	if strings.HasPrefix(v, "=") && !strings.ContainsAny(v, ",*") {
+		return strings.TrimSpace(v[1:]), true
+	}
+	return v, false
+}
+
+// workspaceMembers 工作区成员对应的Cargo.toml
+// tomls: 项目中的Cargo.toml key:所在目录
+func workspaceMembers(ws *CargoToml, tomls map[string]*model.File) []*model.File {
+
+	dir := filepath.Dir(ws.File.Relpath())
+
+	match := func(patterns []string, d string) bool {
+		for _, p := range patterns {
+			if ok, _ := filepath.Match(filepath.Join(dir, filepath.FromSlash(p)), d); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	var members []*model.File
+	for d, f := range tomls {
+		if d != dir && match(ws.Workspace.Members, d) && !match(ws.Workspace.Exclude, d) {
+			members = append(members, f)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Relpath() < members[j].Relpath() })
+
+	return members
+}
+
+// ParseCargoToml 解析Cargo.toml声明的依赖 用于不存在Cargo.lock时
+// ws: 工作区根目录或单个crate的Cargo.toml
+// crates: 工作区中的crate 包含ws本身
+func ParseCargoToml(ws *CargoToml, crates []*CargoToml) *model.DepGraph {
+
+	root := &model.DepGraph{Path: ws.File.Relpath()}
+
+	// 本地crate key:Cargo.toml所在目录
+	local := map[string]*model.DepGraph{}
+	localName := map[string]*model.DepGraph{}
+	for _, c := range crates {
+		node := &model.DepGraph{Name: c.Package.Name, Version: c.version(ws), Path: c.File.Relpath()}
+		local[filepath.Dir(c.File.Relpath())] = node
+		localName[node.Name] = node
+		root.AppendChild(node)
+	}
+
+	_dep := model.NewDepGraphMap(nil, func(s ...string) *model.DepGraph {
+		return &model.DepGraph{Name: s[0], Version: s[1]}
+	}).LoadOrStore
+
+	// 依赖关系 不同crate中同名依赖的版本约束可能不同
+	type edge struct {
+		node, sub *model.DepGraph
+		develop   bool
+	}
+	var edges []edge
+
+	for _, c := range crates {
+		node := local[filepath.Dir(c.File.Relpath())]
+		for _, d := range c.deps(ws) {
+			var sub *model.DepGraph
+			switch {
+			case d.Path != "":
+				// 本地路径依赖 版本号留空
+				if sub = local[d.Path]; sub == nil {
+					sub = _dep(d.Name, "")
+					sub.Source = model.SourcePath
+				}
+			case d.Git != "" && d.Version == "":
+				sub = _dep(d.Name, "")
+				sub.Source = model.SourceGit
+			default:
+				if l, ok := localName[d.Name]; ok {
+					sub = l
+					break
+				}
+				version, exact := exactVersion(d.Version)
+				sub = _dep(d.Name, version)
+				sub.VersionRange = !exact && version != ""
+				sub.Source = model.SourceRegistry
+				if d.Git != "" {
+					sub.Source = model.SourceGit
+				}
+			}
+			if sub != node {
+				edges = append(edges, edge{node: node, sub: sub, develop: d.Develop})
+			}
+		}
+	}
+
+	// 任一crate作为非开发依赖引用的组件
+	prod := map[*model.DepGraph]bool{}
+	for _, e := range edges {
+		if !e.develop {
+			prod[e.sub] = true
+		}
+	}
+
+	// 仅作为开发依赖引用的组件视为开发依赖 工作区中的crate除外
+	for _, e := range edges {
+		if localName[e.sub.Name] != e.sub && e.develop && !prod[e.sub] {
+			e.sub.Develop = true
+		}
+		e.node.AppendChild(e.sub)
+	}
+
+	return root
+}
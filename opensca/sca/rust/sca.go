@@ -2,6 +2,7 @@ package rust
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
@@ -14,16 +15,67 @@ func (sca Sca) Language() model.Language {
 }
 
 func (sca Sca) Filter(relpath string) bool {
-	return filter.RustCargoLock(relpath)
+	return filter.RustCargoLock(relpath) || filter.RustCargoToml(relpath)
+}
+
+func (sca Sca) ParseBinary(file *model.File) *model.DepGraph {
+	return ParseAuditableBinary(file)
 }
 
 func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// map[dir]*File
+	locks := map[string]*model.File{}
+	tomls := map[string]*model.File{}
 	for _, f := range files {
+		dir := filepath.Dir(f.Relpath())
 		if filter.RustCargoLock(f.Relpath()) {
-			root := ParseCargoLock(f)
-			if root != nil && len(root.Children) > 0 {
-				call(f, root)
+			locks[dir] = f
+		}
+		if filter.RustCargoToml(f.Relpath()) {
+			tomls[dir] = f
+		}
+	}
+
+	// workspace 工作区根目录及成员crate 返回后不再单独解析
+	workspace := func(dir string) (*CargoToml, []*CargoToml) {
+		f, ok := tomls[dir]
+		if !ok {
+			return nil, nil
+		}
+		ws := readCargoToml(f)
+		var crates []*CargoToml
+		if ws.Package.Name != "" {
+			crates = append(crates, ws)
+		}
+		if ws.IsWorkspace() {
+			for _, m := range workspaceMembers(ws, tomls) {
+				crates = append(crates, readCargoToml(m))
+				delete(tomls, filepath.Dir(m.Relpath()))
 			}
 		}
+		delete(tomls, dir)
+		return ws, crates
+	}
+
+	// 解析Cargo.lock
+	for dir, f := range locks {
+		ws, crates := workspace(dir)
+		root := ParseCargoLock(f, ws, crates)
+		if root != nil && len(root.Children) > 0 {
+			call(f, root)
+		}
+	}
+
+	// 不存在Cargo.lock时解析Cargo.toml 工作区优先
+	for dir, f := range tomls {
+		if readCargoToml(f).IsWorkspace() {
+			ws, crates := workspace(dir)
+			call(f, ParseCargoToml(ws, crates))
+		}
+	}
+	for dir, f := range tomls {
+		ws, crates := workspace(dir)
+		call(f, ParseCargoToml(ws, crates))
 	}
 }
@@ -4,6 +4,12 @@ import (
 	"context"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/bazel"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/clojure"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/cpp"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/dart"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/dotnet"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/elixir"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/erlang"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/golang"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/groovy"
@@ -14,6 +20,8 @@ import (
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/ruby"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/rust"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/sbom"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/scala"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/swift"
 )
 
 type Sca interface {
@@ -22,6 +30,14 @@ type Sca interface {
 	Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback)
 }
 
+// BinarySca 同时解析可执行文件的检测器
+// 可执行文件不经过Filter 统一以文件名及文件头识别后交由ParseBinary解析
+type BinarySca interface {
+	Sca
+	// ParseBinary 解析可执行文件 不支持的文件返回nil
+	ParseBinary(file *model.File) *model.DepGraph
+}
+
 var AllSca = []Sca{
 	python.Sca{},
 	javascript.Sca{},
@@ -29,8 +45,16 @@ var AllSca = []Sca{
 	ruby.Sca{},
 	rust.Sca{},
 	erlang.Sca{},
+	elixir.Sca{},
 	php.Sca{},
 	java.Sca{},
 	groovy.Sca{},
+	scala.Sca{},
+	clojure.Sca{},
+	bazel.Sca{},
+	dotnet.Sca{},
+	swift.Sca{},
+	dart.Sca{},
+	cpp.Sca{},
 	sbom.Sca{},
 }
@@ -0,0 +1,244 @@
+package scala
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+var (
+	// val akkaVersion = "2.6.20"
+	sbtValReg = regexp.MustCompile(`(?:lazy\s+)?val\s+(\w+)\s*(?::\s*String\s*)?=\s*"([^"]*)"`)
+	// name := "my-app" | ThisBuild / scalaVersion := "2.13.10"
+	sbtSettingReg = regexp.MustCompile(`(?m)^\s*(?:ThisBuild\s*/\s*)?(name|organization|version|scalaVersion)\s*:=\s*("[^"]*"|[\w.]+)`)
+	// "org.typelevel" %% "cats-core" % "2.9.0" % Test
+	sbtModuleReg = regexp.MustCompile(`"([^"\s]+)"\s*(%{1,3})\s*"([^"\s]+)"\s*%\s*("[^"]*"|[\w.]+)(?:\s*%\s*("[^"]*"|[\w.]+))?`)
+	// exclude("commons-logging", "commons-logging")
+	sbtExcludeReg = regexp.MustCompile(`exclude\(\s*"([^"]+)"\s*,\s*"([^"]+)"\s*\)`)
+)
+
+// SbtBuild sbt构建中声明的依赖
+type SbtBuild struct {
+	Organization string
+	Name         string
+	Version      string
+	ScalaVersion string
+	Dependencies []*java.PomDependency
+}
+
+// readText 读取文件内容 忽略注释
+func readText(file *model.File) string {
+	var sb strings.Builder
+	file.ReadLineNoComment(model.CTypeComment, func(line string) {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	})
+	return sb.String()
+}
+
+// scalaBinaryVersion scala二进制版本 例 2.13.10=>2.13 3.3.0=>3
+func scalaBinaryVersion(version string) string {
+	if strings.HasPrefix(version, "3.") {
+		return "3"
+	}
+	if i := strings.Index(version, "."); i != -1 {
+		if j := strings.Index(version[i+1:], "."); j != -1 {
+			return version[:i+1+j]
+		}
+	}
+	return version
+}
+
+// sbtModuleEnd 依赖声明的结束位置 即同层级的,或)
+// 以.开头的续行及括号后的方法调用属于同一声明 例 ("g" % "a" % "v")\n  .exclude("x", "y")
+func sbtModuleEnd(s string) int {
+	// next 下一个非空白字符是否为.
+	next := func(i int) bool {
+		rest := strings.TrimLeft(s[i:], " \t\r\n")
+		return strings.HasPrefix(rest, ".")
+	}
+	depth, quote := 0, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote:
+			if c == '"' {
+				quote = false
+			}
+		case c == '"':
+			quote = true
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ')':
+			if !next(i + 1) {
+				return i
+			}
+		case c == ',' && depth == 0:
+			return i
+		case c == '\n' && depth == 0:
+			if !next(i + 1) {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
+
+// ReadSbtBuild 读取build.sbt及project目录下的scala文件
+// files: 同一构建中的build.sbt及project/*.scala
+func ReadSbtBuild(files []*model.File) *SbtBuild {
+
+	texts := make([]string, len(files))
+	for i, f := range files {
+		texts[i] = readText(f)
+	}
+
+	// 记录字符串变量 key:变量名
+	vars := map[string]string{}
+	for _, text := range texts {
+		for _, m := range sbtValReg.FindAllStringSubmatch(text, -1) {
+			vars[m[1]] = m[2]
+		}
+	}
+	value := func(s string) string {
+		if strings.HasPrefix(s, `"`) {
+			return strings.Trim(s, `"`)
+		}
+		// 例 Versions.akka
+		if v, ok := vars[s[strings.LastIndex(s, ".")+1:]]; ok {
+			return v
+		}
+		return ""
+	}
+
+	// sbt 1.x默认scala版本为2.12
+	build := &SbtBuild{ScalaVersion: "2.12"}
+	for _, text := range texts {
+		for _, m := range sbtSettingReg.FindAllStringSubmatch(text, -1) {
+			v := value(m[2])
+			if v == "" {
+				continue
+			}
+			switch m[1] {
+			case "name":
+				build.Name = v
+			case "organization":
+				build.Organization = v
+			case "version":
+				build.Version = v
+			case "scalaVersion":
+				build.ScalaVersion = v
+			}
+		}
+	}
+
+	exist := map[string]bool{}
+	for _, text := range texts {
+		matches := sbtModuleReg.FindAllStringSubmatchIndex(text, -1)
+		for i, idx := range matches {
+			m := make([]string, len(idx)/2)
+			for j := range m {
+				if idx[2*j] >= 0 {
+					m[j] = text[idx[2*j]:idx[2*j+1]]
+				}
+			}
+			dep := &java.PomDependency{GroupId: m[1], ArtifactId: m[3], Version: value(m[4])}
+			// %%为scala库 需要追加scala二进制版本
+			if m[2] != "%" {
+				dep.ArtifactId += "_" + scalaBinaryVersion(build.ScalaVersion)
+			}
+			switch strings.ToLower(strings.Trim(m[5], `"`)) {
+			case "test", "it", "integrationtest":
+				dep.Scope = "test"
+			case "provided":
+				dep.Scope = "provided"
+			}
+			// 当前声明至下一个依赖声明或声明结束之间的exclude
+			end := len(text)
+			if i+1 < len(matches) {
+				end = matches[i+1][0]
+			}
+			end = idx[1] + sbtModuleEnd(text[idx[1]:end])
+			for _, e := range sbtExcludeReg.FindAllStringSubmatch(text[idx[1]:end], -1) {
+				dep.Exclusions = append(dep.Exclusions, &java.PomDependency{GroupId: e[1], ArtifactId: e[2]})
+			}
+			if dep.Version == "" || exist[dep.Index2()] {
+				continue
+			}
+			exist[dep.Index2()] = true
+			build.Dependencies = append(build.Dependencies, dep)
+		}
+	}
+
+	return build
+}
+
+// ParseSbt 借助pom解析sbt构建的间接依赖
+// path: build.sbt路径
+func ParseSbt(ctx context.Context, path string, build *SbtBuild) *model.DepGraph {
+	root := java.ParseDependencies(ctx, path, build.Dependencies)
+	root.Vendor = build.Organization
+	root.Name = build.Name
+	root.Version = build.Version
+	return root
+}
+
+// SbtLock sbt-dependency-lock生成的build.sbt.lock文件结构
+type SbtLock struct {
+	LockVersion  int `json:"lockVersion"`
+	Dependencies []struct {
+		Org     string `json:"org"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		// compile | runtime | test | provided | optional
+		Configurations []string `json:"configurations"`
+	} `json:"dependencies"`
+}
+
+// ParseSbtLock 解析build.sbt.lock 文件中没有依赖关系 间接依赖同样挂在根节点下
+// build: 对应的sbt构建 不存在时为nil
+func ParseSbtLock(file *model.File, build *SbtBuild) *model.DepGraph {
+
+	lock := SbtLock{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	// build.sbt中声明的为直接依赖
+	direct := map[string]bool{}
+	if build != nil {
+		root.Vendor = build.Organization
+		root.Name = build.Name
+		root.Version = build.Version
+		for _, dep := range build.Dependencies {
+			direct[dep.Index2()] = true
+		}
+	}
+
+	for _, d := range lock.Dependencies {
+		dep := &model.DepGraph{Vendor: d.Org, Name: d.Name, Version: d.Version}
+		// 仅在test配置中使用的为开发依赖
+		dep.Develop = true
+		for _, c := range d.Configurations {
+			if c == "compile" || c == "runtime" {
+				dep.Develop = false
+			}
+		}
+		dep.Indirect = build != nil && !direct[java.PomDependency{GroupId: d.Org, ArtifactId: d.Name}.Index2()]
+		root.AppendChild(dep)
+	}
+
+	return root
+}
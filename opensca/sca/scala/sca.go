@@ -0,0 +1,83 @@
+package scala
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_Java
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.ScalaSbt(relpath) || filter.ScalaProject(relpath) || filter.ScalaSbtLock(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+
+	// 以build.sbt所在目录为单位 key:构建目录
+	sbts := map[string]*model.File{}
+	buildFiles := map[string][]*model.File{}
+	var dirs []string
+	for _, f := range files {
+		if filter.ScalaSbt(f.Relpath()) {
+			dir := filepath.Dir(f.Relpath())
+			if _, ok := sbts[dir]; !ok {
+				sbts[dir] = f
+				dirs = append(dirs, dir)
+			}
+			buildFiles[dir] = append(buildFiles[dir], f)
+		}
+	}
+	for _, f := range files {
+		// project/*.scala属于上级目录的构建
+		if filter.ScalaProject(f.Relpath()) {
+			dir := filepath.Dir(filepath.Dir(f.Relpath()))
+			if _, ok := sbts[dir]; ok {
+				buildFiles[dir] = append(buildFiles[dir], f)
+			}
+		}
+	}
+
+	builds := map[string]*SbtBuild{}
+	for _, dir := range dirs {
+		builds[dir] = ReadSbtBuild(buildFiles[dir])
+	}
+
+	// nearestBuild 锁文件所在目录或最近的上级目录中的构建
+	nearestBuild := func(dir string) (string, *SbtBuild) {
+		for {
+			if b, ok := builds[dir]; ok {
+				return dir, b
+			}
+			up := filepath.Dir(dir)
+			if up == dir {
+				return "", nil
+			}
+			dir = up
+		}
+	}
+
+	// build.sbt.lock > build.sbt
+	locked := map[string]bool{}
+	for _, f := range files {
+		if filter.ScalaSbtLock(f.Relpath()) {
+			dir, build := nearestBuild(filepath.Dir(f.Relpath()))
+			locked[dir] = true
+			call(f, ParseSbtLock(f, build))
+		}
+	}
+
+	for _, dir := range dirs {
+		if locked[dir] {
+			continue
+		}
+		f := sbts[dir]
+		call(f, ParseSbt(ctx, f.Relpath(), builds[dir]))
+	}
+}
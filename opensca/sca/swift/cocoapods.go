@@ -0,0 +1,108 @@
+package swift
+
+import (
+	"io"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PodfileLock Podfile.lock文件结构
+type PodfileLock struct {
+	// 例 - Alamofire (5.8.0) | - Firebase/Core (10.18.0): [- FirebaseCore (= 10.18.0)]
+	Pods []any `yaml:"PODS"`
+	// Podfile中声明的依赖
+	Dependencies []string `yaml:"DEPENDENCIES"`
+	// 非仓库来源的pod 例 MyLib: {:path: ../MyLib}
+	ExternalSources map[string]map[string]string `yaml:"EXTERNAL SOURCES"`
+}
+
+// podNameVersion 解析pod 子模块合并到所属pod 例 Firebase/Core (10.18.0) => Firebase 10.18.0
+func podNameVersion(s string) (name, version string) {
+	s = strings.Trim(strings.TrimSpace(s), `"`)
+	name, version, _ = strings.Cut(s, " ")
+	name, _, _ = strings.Cut(name, "/")
+	version = strings.Trim(strings.TrimSpace(version), "()")
+	return
+}
+
+// ParsePodfileLock 解析Podfile.lock
+func ParsePodfileLock(file *model.File) *model.DepGraph {
+
+	lock := PodfileLock{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := yaml.NewDecoder(reader).Decode(&lock); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	// 记录pod及依赖的pod key:pod名
+	depMap := map[string]*model.DepGraph{}
+	deps := map[string][]string{}
+	var names []string
+	add := func(s string, subs []string) {
+		name, version := podNameVersion(s)
+		if _, ok := depMap[name]; !ok {
+			depMap[name] = &model.DepGraph{Name: name, Version: version, Language: model.Lan_ObjectiveC}
+			names = append(names, name)
+		}
+		for _, sub := range subs {
+			subName, _ := podNameVersion(sub)
+			deps[name] = append(deps[name], subName)
+		}
+	}
+	for _, pod := range lock.Pods {
+		switch p := pod.(type) {
+		case string:
+			add(p, nil)
+		case map[string]any:
+			for k, v := range p {
+				var subs []string
+				if list, ok := v.([]any); ok {
+					for _, sub := range list {
+						if s, ok := sub.(string); ok {
+							subs = append(subs, s)
+						}
+					}
+				}
+				add(k, subs)
+			}
+		}
+	}
+
+	// 本地pod 版本号留空
+	for name, source := range lock.ExternalSources {
+		if _, ok := source[":path"]; ok {
+			if dep, ok := depMap[name]; ok {
+				dep.Version = ""
+			}
+		}
+	}
+
+	// 记录依赖关系 忽略同一pod的子模块间的依赖
+	for _, name := range names {
+		for _, sub := range deps[name] {
+			if sub != name {
+				depMap[name].AppendChild(depMap[sub])
+			}
+		}
+	}
+
+	root := &model.DepGraph{Path: file.Relpath()}
+	for _, d := range lock.Dependencies {
+		name, _ := podNameVersion(d)
+		root.AppendChild(depMap[name])
+	}
+	if len(root.Children) == 0 {
+		for _, name := range names {
+			if len(depMap[name].Parents) == 0 {
+				root.AppendChild(depMap[name])
+			}
+		}
+	}
+
+	return root
+}
@@ -0,0 +1,30 @@
+package swift
+
+import (
+	"context"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/filter"
+)
+
+type Sca struct{}
+
+func (sca Sca) Language() model.Language {
+	return model.Lan_Swift
+}
+
+func (sca Sca) Filter(relpath string) bool {
+	return filter.SwiftPackageResolved(relpath) || filter.SwiftPodfileLock(relpath)
+}
+
+func (sca Sca) Sca(ctx context.Context, parent *model.File, files []*model.File, call model.ResCallback) {
+	for _, f := range files {
+		if filter.SwiftPackageResolved(f.Relpath()) {
+			call(f, ParsePackageResolved(f))
+		}
+		// CocoaPods组件语言为ObjectiveC
+		if filter.SwiftPodfileLock(f.Relpath()) {
+			call(f, ParsePodfileLock(f))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package swift
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/logs"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+)
+
+// PackageResolved Package.resolved文件结构 兼容v1/v2/v3
+type PackageResolved struct {
+	Version int `json:"version"`
+	// v1
+	Object struct {
+		Pins []ResolvedPin `json:"pins"`
+	} `json:"object"`
+	// v2/v3
+	Pins []ResolvedPin `json:"pins"`
+}
+
+// ResolvedPin 锁定的包
+type ResolvedPin struct {
+	// v1
+	Package       string `json:"package"`
+	RepositoryURL string `json:"repositoryURL"`
+	// v2/v3
+	Identity string `json:"identity"`
+	// remoteSourceControl | localSourceControl | registry
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+	State    struct {
+		Branch   string `json:"branch"`
+		Revision string `json:"revision"`
+		Version  string `json:"version"`
+	} `json:"state"`
+}
+
+// repoVendorName 从仓库地址获取厂商及名称 例 https://github.com/apple/swift-nio.git => github.com/apple swift-nio
+func repoVendorName(location string) (vendor, name string) {
+	location = strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+	// git@github.com:apple/swift-nio
+	if i := strings.Index(location, "@"); i != -1 && !strings.Contains(location, "://") {
+		location = strings.Replace(location[i+1:], ":", "/", 1)
+	} else if u, err := url.Parse(location); err == nil && u.Host != "" {
+		location = u.Host + u.Path
+	}
+	return path.Dir(location), path.Base(location)
+}
+
+// ParsePackageResolved 解析Package.resolved 文件中没有依赖关系 均作为直接依赖
+func ParsePackageResolved(file *model.File) *model.DepGraph {
+
+	resolved := PackageResolved{}
+	file.OpenReader(func(reader io.Reader) {
+		if err := json.NewDecoder(reader).Decode(&resolved); err != nil {
+			logs.Warnf("parse %s fail:%s", file.Relpath(), err)
+		}
+	})
+
+	pins := resolved.Pins
+	if resolved.Version <= 1 {
+		pins = resolved.Object.Pins
+	}
+
+	root := &model.DepGraph{Path: file.Relpath()}
+
+	for _, pin := range pins {
+		location := pin.Location
+		if location == "" {
+			location = pin.RepositoryURL
+		}
+		dep := &model.DepGraph{Version: pin.State.Version}
+		switch pin.Kind {
+		case "localSourceControl", "fileSystem":
+			// 本地包 版本号留空
+			dep.Name = pin.Identity
+			dep.Version = ""
+		case "registry":
+			// 例 mona.LinkedList
+			dep.Vendor, dep.Name, _ = strings.Cut(pin.Identity, ".")
+		default:
+			dep.Vendor, dep.Name = repoVendorName(location)
+		}
+		if dep.Name == "" {
+			dep.Name = pin.Identity
+			if dep.Name == "" {
+				dep.Name = pin.Package
+			}
+		}
+		root.AppendChild(dep)
+	}
+
+	return root
+}
@@ -0,0 +1,44 @@
+package bazel
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/bazel"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Bazel(t *testing.T) {
+
+	java.RegisterMavenOrigin(tool.MavenOrigin("repo"))
+
+	failureaccess := tool.Dep3("com.google.guava", "failureaccess", "1.0.1")
+	hamcrest := tool.DevDep3("org.hamcrest", "hamcrest-core", "1.3")
+
+	tool.RunTaskCase(t, bazel.Sca{})([]tool.TaskCase{
+
+		// MODULE.bazel
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("my_app", "1.0.0",
+			tool.Dep3("com.google.guava", "guava", "32.1.2-jre", failureaccess),
+			tool.DevDep3("junit", "junit", "4.13.2",
+				tool.DevDep3("org.hamcrest", "hamcrest-core", "1.3"),
+			),
+		))},
+
+		// MODULE.bazel & maven_install.json v2
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("locked", "2.0",
+			tool.Dep3("com.google.guava", "guava", "32.1.2-jre",
+				tool.Dep3("com.google.code.findbugs", "jsr305", "3.0.2"),
+				failureaccess,
+			),
+			tool.Dep3("junit", "junit", "4.13.2", hamcrest),
+			hamcrest,
+		))},
+
+		// maven_install.json v1 (跳过无法解析的坐标)
+		{Path: "3", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep3("com.google.guava", "guava", "31.1-jre", failureaccess),
+			tool.Dep3("io.netty", "netty-transport-native-epoll", "4.1.94.Final"),
+		))},
+	})
+}
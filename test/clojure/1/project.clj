@@ -0,0 +1,16 @@
+;; Leiningen项目
+(defproject com.example/my-app "0.1.0-SNAPSHOT"
+  :description "FIXME: write description"
+  :url "http://example.com/FIXME"
+  :license {:name "EPL-2.0"
+            :url "https://www.eclipse.org/legal/epl-2.0/"}
+  :managed-dependencies [[cheshire "5.11.0"]]
+  :dependencies [[org.clojure/clojure "1.11.1"]
+                 [ring/ring-core "1.9.6" :exclusions [commons-codec]]
+                 [cheshire]
+                 #_[ignored/lib "1.0"]]
+  :main ^:skip-aot my-app.core
+  :repositories [["private" {:url "https://repo.example.com/maven"}]]
+  :profiles {:dev {:dependencies [[midje "1.10.9"]]}
+             :uberjar {:aot :all
+                       :jvm-opts ["-Dclojure.compiler.direct-linking=true"]}})
@@ -0,0 +1,42 @@
+package clojure
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/clojure"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Clojure(t *testing.T) {
+
+	java.RegisterMavenOrigin(tool.MavenOrigin("repo"))
+
+	clj := tool.Dep3("org.clojure", "clojure", "1.11.1",
+		tool.Dep3("org.clojure", "spec.alpha", "0.3.218"),
+	)
+
+	tool.RunTaskCase(t, clojure.Sca{})([]tool.TaskCase{
+
+		// project.clj
+		{Path: "1", Result: tool.Dep("", "", tool.Dep3("com.example", "my-app", "0.1.0-SNAPSHOT",
+			clj,
+			tool.Dep3("ring", "ring-core", "1.9.6",
+				tool.Dep3("crypto-random", "crypto-random", "1.2.1"),
+			),
+			tool.Dep3("cheshire", "cheshire", "5.11.0"),
+			tool.DevDep3("midje", "midje", "1.10.9"),
+		))},
+
+		// deps.edn
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep3("io.github.example", "util", "v0.2.0"),
+			tool.Dep3("local", "lib", ""),
+			clj,
+			tool.Dep3("ring", "ring-core", "1.9.6",
+				tool.Dep3("commons-codec", "commons-codec", "1.15"),
+			),
+			tool.DevDep3("org.clojure", "tools.logging", "1.2.4"),
+		))},
+	})
+}
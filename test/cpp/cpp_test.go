@@ -0,0 +1,76 @@
+package cpp
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/common"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/cpp"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Cpp(t *testing.T) {
+
+	registry, _ := filepath.Abs("registry")
+	cpp.RegisterVcpkgRegistry(common.RepoConfig{Url: "file://" + filepath.ToSlash(registry)})
+
+	v := model.VendorVcpkg
+	zlib := tool.Dep("zlib", "1.2.11")
+	openssl := tool.Dep("openssl", "1.1.1k", zlib)
+
+	tool.RunTaskCase(t, cpp.Sca{})([]tool.TaskCase{
+
+		// conan.lock v0.4
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("", "",
+			openssl,
+			tool.Dep("libcurl", "7.78.0", openssl, zlib),
+			tool.DevDep("cmake", "3.21.3"),
+		))},
+
+		// conan.lock 2.x & conanfile.txt
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("zlib", "1.3"),
+			tool.Dep("openssl", "3.2.1"),
+			tool.Dep("fmt", "10.2.1"),
+			tool.DevDep("cmake", "3.28.1"),
+		))},
+
+		// conanfile.txt
+		{Path: "3", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("zlib", "1.2.13"),
+			tool.Dep("boost", "1.83.0"),
+			tool.Dep("openssl", ">=1.1 <4"),
+			tool.DevDep("ninja", "1.11.1"),
+		))},
+
+		// vcpkg.json & vcpkg-configuration.json
+		{Path: "4", Result: tool.Dep("", "", tool.Dep("my-app", "1.0.0",
+			tool.Dep3(v, "zlib", "1.3"),
+			tool.Dep3(v, "openssl", "3.3.0"),
+			tool.Dep3(v, "curl", "8.4.0"),
+			tool.Dep3(v, "fmt", "9.1.0"),
+			tool.DevDep3(v, "vcpkg-cmake", "2023-05-04"),
+		))},
+
+		// vcpkg.json 无法获取基线
+		{Path: "5", Result: tool.Dep("", "", tool.Dep("offline", "2024.01",
+			tool.Dep3(v, "zlib", ""),
+			tool.Dep3(v, "openssl", ">=3.3.0"),
+		))},
+
+		// conan.lock v0.4 根节点id不为0
+		{Path: "6", Result: tool.Dep("", "", tool.Dep("myapp", "1.0",
+			tool.Dep("zlib", "1.2.11"),
+			tool.DevDep("cmake", "3.21.3"),
+		))},
+	})
+}
+
+func Test_VcpkgPurl(t *testing.T) {
+	purl := model.Purl(model.VendorVcpkg, "zlib", "1.3", model.Lan_Cpp)
+	vendor, name, version, language := model.ParsePurl(purl)
+	if vendor != model.VendorVcpkg || name != "zlib" || version != "1.3" || language != model.Lan_Cpp {
+		t.Errorf("purl:%s vendor:%s name:%s version:%s language:%s", purl, vendor, name, version, language)
+	}
+}
@@ -0,0 +1,21 @@
+package dart
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/dart"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Dart(t *testing.T) {
+	tool.RunTaskCase(t, dart.Sca{})([]tool.TaskCase{
+
+		// pubspec.lock & pubspec.yaml (忽略sdk中的包)
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("my_app", "1.2.0+3",
+			tool.DevDep("flutter_lints", "3.0.1"),
+			tool.Dep("http", "1.1.0"),
+			tool.Dep("http_parser", "4.0.2"),
+			tool.Dep("shared", ""),
+		))},
+	})
+}
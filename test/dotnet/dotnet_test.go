@@ -0,0 +1,47 @@
+package dotnet
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/dotnet"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_DotNet(t *testing.T) {
+
+	newtonsoft := tool.Dep("Newtonsoft.Json", "13.0.1")
+	primitives := tool.Dep("Microsoft.Extensions.Primitives", "6.0.0")
+
+	tool.RunTaskCase(t, dotnet.Sca{})([]tool.TaskCase{
+
+		// packages.lock.json
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("App", "",
+			tool.Dep("lib", "", newtonsoft),
+			tool.Dep("Serilog.Sinks.Console", "5.0.0",
+				tool.Dep("Serilog", "3.1.0"),
+				tool.Dep("Serilog", "3.1.1"),
+			),
+		))},
+
+		// obj/project.assets.json
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("Worker", "",
+			tool.Dep("Microsoft.Extensions.Logging", "6.0.0",
+				tool.Dep("Microsoft.Extensions.Logging.Abstractions", "6.0.0"),
+				tool.Dep("Microsoft.Extensions.Options", "6.0.0", primitives),
+			),
+		))},
+
+		// *.csproj & Directory.Packages.props
+		{Path: "3", Result: tool.Dep("", "", tool.Dep("App", "",
+			tool.Dep("AutoMapper", "[12.0.0,13.0.0)"),
+			tool.Dep("Dapper", "2.1.24"),
+			tool.Dep("Polly", "8.1.0"),
+		))},
+
+		// packages.config
+		{Path: "4", Result: tool.Dep("", "", tool.Dep("Legacy", "",
+			tool.Dep("log4net", "2.0.15"),
+			tool.DevDep("NUnit", "3.13.3"),
+		))},
+	})
+}
@@ -0,0 +1,30 @@
+package elixir
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/elixir"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Elixir(t *testing.T) {
+
+	tool.RunTaskCase(t, elixir.Sca{})([]tool.TaskCase{
+
+		// mix.lock & mix.exs
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("my_app", "",
+			tool.Dep("plug_cowboy", "2.6.1",
+				tool.Dep("cowboy", "2.10.0",
+					tool.Dep("cowlib", "2.12.1"),
+					tool.Dep("ranch", "1.8.0"),
+				),
+			),
+			tool.Dep("jason", "1.4.1"),
+			tool.DevDep("credo", "1.7.1",
+				tool.DevDep("bunt", "0.2.1"),
+			),
+			tool.DevDep("ex_doc", "0.30.9"),
+			tool.Dep("my_lib", ""),
+		))},
+	})
+}
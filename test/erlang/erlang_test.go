@@ -0,0 +1,35 @@
+package erlang
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/erlang"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Erlang(t *testing.T) {
+	tool.RunTaskCase(t, erlang.Sca{})([]tool.TaskCase{
+
+		// rebar.lock v2 & rebar.config
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("cowboy", "2.10.0"),
+			tool.Dep("cowlib", "2.12.1"),
+			tool.Dep("lager", ""),
+			tool.Dep("ranch", "1.8.0"),
+			tool.Dep("jsx", "3.1.0"),
+		))},
+
+		// rebar.lock v1
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("certifi", "2.9.0"),
+			tool.Dep("hackney", "1.18.1"),
+			tool.Dep("mochiweb", "v3.1.1"),
+		))},
+
+		// rebar.lock (二进制中的字符及符号)
+		{Path: "3", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("cowboy", "2.10.0"),
+			tool.Dep("jsx", "3.1.0"),
+		))},
+	})
+}
@@ -0,0 +1,10 @@
+module example.com/app
+
+go 1.21
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/text v0.3.0 // indirect
+)
+
+replace golang.org/x/text v0.3.0 => golang.org/x/text v0.3.8
@@ -0,0 +1,10 @@
+module example.com/a
+
+go 1.21
+
+require (
+	example.com/b v0.0.0
+	example.com/x v1.0.0
+)
+
+replace example.com/b => ../b
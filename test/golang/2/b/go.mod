@@ -0,0 +1,8 @@
+module example.com/b
+
+go 1.21
+
+require (
+	example.com/x v1.1.0 // indirect
+	example.com/y v1.0.0
+)
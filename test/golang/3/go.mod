@@ -0,0 +1,17 @@
+module example.com/app
+
+go 1.16
+
+require (
+	example.com/bad v1.0.0
+	example.com/lib v0.0.0
+	github.com/pkg/errors v0.9.1
+	golang.org/x/text v0.3.0 // indirect
+)
+
+exclude example.com/bad v1.0.0
+
+replace (
+	example.com/lib => ./lib
+	example.com/util => ./util
+)
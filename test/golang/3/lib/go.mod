@@ -0,0 +1,8 @@
+module example.com/lib
+
+go 1.16
+
+require (
+	example.com/util v0.0.0
+	golang.org/x/text v0.3.7
+)
@@ -0,0 +1,9 @@
+module example.com/app
+
+go 1.21
+
+require (
+	example.com/a v1.0.0
+	example.com/b v1.0.0
+	example.com/private v1.0.0
+)
@@ -0,0 +1,5 @@
+module example.com/app
+
+go 1.16
+
+require example.com/a v1.0.0
@@ -0,0 +1,7 @@
+package lib
+
+import "fmt"
+
+func Hello() {
+	fmt.Println("hello")
+}
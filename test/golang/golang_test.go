@@ -0,0 +1,121 @@
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/golang"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Golang(t *testing.T) {
+
+	x := tool.Dep("example.com/x", "v1.1.0")
+	errors := tool.Dep("github.com/pkg/errors", "v0.9.1")
+	text := tool.Dep("golang.org/x/text", "v0.3.7")
+
+	t.Setenv("GOPROXY", "off")
+
+	tool.RunTaskCase(t, golang.Sca{})([]tool.TaskCase{
+
+		// vendor/modules.txt
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("example.com/app", "",
+			tool.Dep("github.com/pkg/errors", "v0.9.1"),
+			tool.Dep("golang.org/x/text", "v0.3.8"),
+		))},
+
+		// go.work
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("example.com/a", "",
+				tool.Dep("example.com/b", "",
+					x,
+					tool.Dep("example.com/y2", "v2.0.0"),
+				),
+				x,
+			),
+		))},
+
+		// go.mod (local replace & exclude & indirect) & go.sum
+		{Path: "3", Result: tool.Dep("", "",
+			tool.Dep("example.com/app", "",
+				tool.Dep("example.com/lib", "",
+					tool.Dep("example.com/util", "", errors),
+					text,
+				),
+				errors,
+				text,
+				tool.Dep("github.com/extra/pkg", "v1.0.0"),
+			),
+			tool.Dep("example.com/lib", "",
+				tool.Dep("example.com/util", "v0.0.0"),
+				tool.Dep("golang.org/x/text", "v0.3.7"),
+			),
+			tool.Dep("example.com/util", "",
+				tool.Dep("github.com/pkg/errors", "v0.9.1"),
+			),
+		)},
+
+		// go.mod & vendor/modules.txt (vendor版本优先 & go.mod未记录的间接依赖)
+		{Path: "5", Result: tool.Dep("", "", tool.Dep("example.com/app", "",
+			tool.Dep("example.com/a", "v1.1.0"),
+			tool.Dep("example.com/b", "v1.0.0"),
+		))},
+	})
+
+	// GOPROXY
+	proxy, _ := filepath.Abs("goproxy")
+	t.Setenv("GOPROXY", "file://"+filepath.ToSlash(proxy))
+	t.Setenv("GONOPROXY", "example.com/private")
+
+	c := tool.Dep("example.com/c", "v1.2.0")
+
+	tool.RunTaskCase(t, golang.Sca{})([]tool.TaskCase{
+
+		// go.mod & GOPROXY (MVS & GONOPROXY)
+		{Path: "4", Result: tool.Dep("", "", tool.Dep("example.com/app", "",
+			tool.Dep("example.com/a", "v1.0.0", c),
+			tool.Dep("example.com/b", "v1.0.0",
+				c,
+				tool.Dep("github.com/Sirupsen/logrus", "v1.0.0"),
+			),
+			tool.Dep("example.com/private", "v1.0.0"),
+		))},
+	})
+
+	// go二进制文件
+	bin := t.TempDir()
+	cmd := exec.Command("go", "build", "-buildvcs=false", "-o", filepath.Join(bin, "app"), ".")
+	cmd.Dir = "binary"
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%s\n%s", err, out)
+	}
+	os.WriteFile(filepath.Join(bin, "README"), []byte("not a binary"), 0644)
+
+	tool.RunTaskCase(t, golang.Sca{})([]tool.TaskCase{
+		{Path: bin, Result: tool.Dep("", "", tool.Dep("example.com/app", "",
+			tool.Dep("stdlib", "v"+strings.TrimPrefix(runtime.Version(), "go")),
+			tool.Dep("example.com/lib", "v1.0.0"),
+		))},
+	})
+
+}
+
+func Test_GolangVendor(t *testing.T) {
+	vendor := model.NewFile("5/vendor/modules.txt", "5/vendor/modules.txt")
+	for _, modfile := range []*model.File{model.NewFile("5/go.mod", "5/go.mod"), nil} {
+		indirect := map[string]bool{}
+		golang.ParseGomodWithVendor(modfile, vendor).ForEachNode(func(p, n *model.DepGraph) bool {
+			indirect[n.Name] = n.Indirect
+			return true
+		})
+		if indirect["example.com/a"] || !indirect["example.com/b"] {
+			t.Errorf("go.mod:%v indirect:%v", modfile != nil, indirect)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+module example.com/c
+
+go 1.21
+
+require example.com/app v0.1.0
@@ -0,0 +1,3 @@
+module example.com/private
+
+require example.com/c v1.1.0
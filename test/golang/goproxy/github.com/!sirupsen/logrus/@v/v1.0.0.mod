@@ -0,0 +1 @@
+module github.com/Sirupsen/logrus
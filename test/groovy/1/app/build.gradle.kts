@@ -0,0 +1,13 @@
+plugins {
+    java
+    alias(libs.plugins.spring.boot)
+}
+
+dependencies {
+    implementation(libs.guava)
+    implementation(libs.commons.lang3)
+    implementation(libs.slf4j.api)
+    // implementation(libs.hamcrest)
+    testImplementation(libs.bundles.testing)
+    println(libs.versions.guava.get())
+}
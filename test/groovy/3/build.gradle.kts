@@ -0,0 +1,30 @@
+plugins {
+    `java-library`
+    kotlin("kapt") version "1.9.0"
+}
+
+val lang3Version = "3.12.0"
+extra["junitVersion"] = "4.13.2"
+
+configurations.all {
+    exclude(group = "commons-logging", module = "commons-logging")
+}
+
+dependencies {
+    implementation(platform("com.example:demo-bom:1.0.0"))
+    implementation("com.google.guava:guava") {
+        exclude(group = "com.google.guava", module = "failureaccess")
+    }
+    implementation("org.apache.commons:commons-lang3:$lang3Version")
+    "implementation"("com.example:demo-lib")
+    /* implementation("org.slf4j:slf4j-api:2.0.7") */
+    compileOnly("org.projectlombok:lombok:1.18.30")
+    kapt("com.example:demo-processor:1.0.0")
+    testImplementation("junit:junit:${extra["junitVersion"]}")
+
+    constraints {
+        implementation("org.hamcrest:hamcrest-core:1.3") {
+            because("demo-lib uses an outdated version")
+        }
+    }
+}
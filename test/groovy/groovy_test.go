@@ -0,0 +1,64 @@
+package groovy
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/groovy"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Groovy(t *testing.T) {
+
+	java.RegisterMavenOrigin(tool.MavenOrigin("repo"))
+
+	hamcrest := tool.DevDep3("org.hamcrest", "hamcrest-core", "1.3")
+
+	tool.RunTaskCase(t, groovy.Sca{})([]tool.TaskCase{
+
+		// libs.versions.toml
+		{Path: "1", Result: tool.Dep("", "",
+			tool.Dep("", "",
+				tool.Dep3("com.google.guava", "guava", "32.1.2-jre",
+					tool.Dep3("com.google.guava", "failureaccess", "1.0.1"),
+				),
+				tool.Dep3("org.apache.commons", "commons-lang3", "3.12.0"),
+				tool.DevDep3("junit", "junit", "4.13.2", hamcrest),
+				hamcrest,
+			),
+			tool.Dep("", ""),
+		)},
+
+		// gradle.lockfile & buildscript-gradle.lockfile
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep3("com.google.code.findbugs", "jsr305", "3.0.2"),
+			tool.Dep3("com.google.guava", "failureaccess", "1.0.1"),
+			tool.Dep3("com.google.guava", "guava", "32.1.2-jre"),
+			tool.DevDep3("junit", "junit", "4.13.2"),
+			tool.DevDep3("org.hamcrest", "hamcrest-core", "1.3"),
+			tool.DevDep3("com.github.ben-manes", "gradle-versions-plugin", "0.47.0"),
+		))},
+
+		// kotlin dsl & platform & constraints & exclude
+		{Path: "3", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep3("com.google.guava", "guava", "32.1.2-jre"),
+			tool.Dep3("org.apache.commons", "commons-lang3", "3.12.0"),
+			tool.Dep3("com.example", "demo-lib", "1.0.0",
+				tool.Dep3("org.hamcrest", "hamcrest-core", "1.3"),
+			),
+			tool.DevDep3("com.example", "demo-processor", "1.0.0"),
+			tool.DevDep3("junit", "junit", "4.13.2"),
+		))},
+
+		// groovy dsl & enforcedPlatform & transitive
+		{Path: "4", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep3("org.apache.commons", "commons-lang3", "3.12.0"),
+			tool.Dep3("com.google.guava", "guava", "32.1.2-jre"),
+			tool.Dep3("com.example", "demo-lib", "1.0.0",
+				tool.Dep3("commons-logging", "commons-logging", "1.2"),
+			),
+			tool.DevDep3("com.example", "demo-processor", "1.0.0"),
+			tool.DevDep3("junit", "junit", "4.13.2", hamcrest),
+		))},
+	})
+}
@@ -1,6 +1,9 @@
 package java
 
 import (
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/common"
@@ -213,3 +216,163 @@ func Test_JavaWithStatic(t *testing.T) {
 func Test_JavaWithMvn(t *testing.T) {
 	tool.RunTaskCase(t, java.Sca{NotUseStatic: true})(cases)
 }
+
+var mediationCases = []tool.TaskCase{
+
+	// 路径最短的依赖优先 路径长度相同时先声明的优先
+	{Path: "19", Result: tool.Dep("", "",
+		tool.Dep3("foo", "demo", "1.0",
+			tool.Dep3("com.test", "a", "1.0",
+				tool.Dep3("com.test", "c", "1.0"),
+				tool.Dep3("com.test", "y", "1.0"),
+			),
+			tool.Dep3("com.test", "b", "1.0",
+				tool.Dep3("com.test", "x", "2.0"),
+				tool.Dep3("org.other", "z", "1.0"),
+			),
+		),
+	)},
+
+	// 版本范围使用maven-metadata.xml中满足范围的最高版本
+	{Path: "20", Result: tool.Dep("", "",
+		tool.Dep3("foo", "demo", "1.0",
+			tool.Dep3("com.test", "r", "1.5",
+				tool.Dep3("com.test", "t", "2.0"),
+			),
+			tool.Dep3("com.test", "s", "1.1"),
+		),
+	)},
+
+	// exclusion支持通配符
+	{Path: "21", Result: tool.Dep("", "",
+		tool.Dep3("foo", "demo", "1.0",
+			tool.Dep3("com.test", "a", "1.0"),
+			tool.Dep3("com.test", "b", "1.0",
+				tool.Dep3("org.other", "z", "1.0"),
+			),
+		),
+	)},
+
+	// 项目中的模块使用项目中的版本
+	{Path: "22", Result: tool.Dep("", "",
+		tool.Dep3("foo", "parent", "2.0"),
+		tool.Dep3("foo", "core", "2.0",
+			tool.Dep3("com.test", "x", "1.0"),
+		),
+		tool.Dep3("foo", "app", "2.0",
+			tool.Dep3("foo", "core", "2.0"),
+			tool.Dep3("com.test", "x", "2.0"),
+		),
+	)},
+
+	// 不同父组件下路径长度相同时先声明的优先 被忽略版本的子依赖不再解析 更深的同名依赖让位于较浅的
+	{Path: "25", Result: tool.Dep("", "",
+		tool.Dep3("foo", "demo", "1.0",
+			tool.Dep3("com.test", "m", "1.0",
+				tool.Dep3("com.test", "p", "1.0"),
+			),
+			tool.Dep3("com.test", "n", "1.0",
+				tool.Dep3("com.test", "q", "2.0"),
+			),
+		),
+	)},
+}
+
+// registerMavenRepoDir 使用本地目录作为maven数据源 测试结束后恢复
+func registerMavenRepoDir(t *testing.T, dir string) {
+	java.RegisterMavenOrigin(tool.MavenOrigin(dir))
+	java.RegisterMavenMetadataOrigin(tool.MavenMetadataOrigin(dir))
+	t.Cleanup(java.ResetMavenOrigin)
+	t.Cleanup(java.ResetMavenMetadataOrigin)
+}
+
+func Test_JavaMediation(t *testing.T) {
+	registerMavenRepoDir(t, "repo")
+	tool.RunTaskCase(t, java.Sca{NotUseMvn: true})(mediationCases)
+}
+
+var profileCases = []tool.TaskCase{
+
+	// profile激活条件
+	{Path: "23", Result: tool.Dep("", "",
+		tool.Dep3("foo", "demo", "1.0",
+			tool.Dep3("com.test", "y", "1.0"),
+			tool.Dep3("com.test", "c", "1.0"),
+			tool.Dep3("com.test", "s", "1.1"),
+			tool.Dep3("com.test", "t", "2.0"),
+			tool.Dep3("com.test", "x", "2.0"),
+		),
+	)},
+
+	// 没有其他生效的profile时activeByDefault生效
+	{Path: "24", Result: tool.Dep("", "",
+		tool.Dep3("foo", "demo", "1.0",
+			tool.Dep3("com.test", "a", "1.0",
+				tool.Dep3("com.test", "c", "1.0",
+					tool.Dep3("com.test", "x", "1.0"),
+				),
+				tool.Dep3("com.test", "y", "1.0"),
+			),
+		),
+	)},
+
+	// 指定的profile及settings.xml中的属性不作用于依赖的pom
+	{Path: "26", Result: tool.Dep("", "",
+		tool.Dep3("foo", "demo", "1.0",
+			tool.Dep3("com.test", "e", "1.0",
+				tool.Dep3("com.test", "x", "1.0"),
+				tool.Dep3("com.test", "y", "1.0"),
+			),
+		),
+	)},
+}
+
+func Test_JavaProfile(t *testing.T) {
+
+	f, err := os.Open("settings.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	settings := java.ReadMavenSettings(f)
+	repos := settings.Repos()
+	expect := []common.RepoConfig{
+		{Url: "https://nexus.example.com/repository/maven-public/", Username: "deployer", Password: "secret"},
+		{Url: "https://repo.example.com/maven2"},
+	}
+	if !reflect.DeepEqual(repos, expect) {
+		t.Errorf("settings repos:%v std:%v", repos, expect)
+	}
+	if local := settings.LocalRepo(); local != "repo" {
+		t.Errorf("settings local repo:%s std:repo", local)
+	}
+
+	mirrors := java.ReadMavenSettings(strings.NewReader(`<settings><mirrors>
+		<mirror><id>a</id><mirrorOf>external:*,!snapshots</mirrorOf><url>https://a.example.com</url></mirror>
+		<mirror><id>b</id><mirrorOf>central,internal</mirrorOf><url>https://b.example.com</url></mirror>
+	</mirrors></settings>`))
+	mirrorCases := []struct {
+		id, url string
+		std     bool
+	}{
+		{"central", "https://repo1.maven.org/maven2", true},
+		{"", "https://repo.example.com/maven2", true},
+		{"snapshots", "https://repo.example.com/snapshots", false},
+		{"internal", "http://localhost:8081/repository", true},
+		{"local", "http://localhost:8081/repository", false},
+		{"file", "file:///root/.m2/repository", false},
+	}
+	for _, c := range mirrorCases {
+		if mirrors.MirrorOf(c.id, c.url) != c.std {
+			t.Errorf("mirrorOf %s %s std:%v", c.id, c.url, c.std)
+		}
+	}
+
+	registerMavenRepoDir(t, "repo")
+	java.RegisterMavenProfiles("extra", "!skipped")
+	java.RegisterMavenProperties(map[string]string{"java.version": "17.0.2"})
+	java.RegisterMavenSettings("settings.xml")
+	t.Cleanup(java.ResetMavenProfiles)
+	t.Cleanup(java.ResetMavenSettings)
+	tool.RunTaskCase(t, java.Sca{NotUseMvn: true})(profileCases)
+}
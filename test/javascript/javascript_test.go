@@ -13,6 +13,8 @@ func Test_JavaScript(t *testing.T) {
 		tool.Dep("ansi-regex", "5.0.1"),
 	)
 
+	colorName := tool.Dep("color-name", "1.1.4")
+
 	std := tool.Dep("", "",
 		tool.Dep("js-test", "1.0.1",
 			tool.Dep("cliui", "6.0.0",
@@ -44,5 +46,55 @@ func Test_JavaScript(t *testing.T) {
 		{Path: "4", Result: std},
 		// simple
 		{Path: "5", Result: std},
+		// pnpm-lock.yaml v5
+		{Path: "6", Result: std},
+		// pnpm-lock.yaml v6
+		{Path: "7", Result: std},
+		// pnpm-lock.yaml v9
+		{Path: "8", Result: std},
+		// pnpm workspace
+		{Path: "9", Result: tool.Dep("", "",
+			tool.Dep("js-workspace", "1.0.0",
+				tool.DevDep("emoji-regex", "8.0.0"),
+			),
+			std.Children[0],
+		)},
+		// yarn.lock (yarn3)
+		{Path: "10", Result: std},
+		// yarn.lock (yarn4) alias & workspace
+		{Path: "11", Result: tool.Dep("", "",
+			tool.Dep("js-test", "1.0.1",
+				tool.Dep("cliui", "6.0.0",
+					tool.Dep("string-width", "4.2.3",
+						tool.Dep("emoji-regex", "8.0.0"),
+						tool.Dep("is-fullwidth-code-point", "3.0.0"),
+						ansi,
+					),
+					ansi,
+					tool.Dep("wrap-ansi", "6.2.0",
+						tool.Dep("ansi-styles", "4.3.0",
+							tool.Dep("color-convert", "2.0.1", colorName),
+						),
+					),
+				),
+				colorName,
+			),
+		)},
+		// package-lock.json workspace
+		{Path: "12", Result: tool.Dep("", "",
+			tool.Dep("js-workspace", "1.0.0"),
+			std.Children[0],
+			tool.Dep("js-lib", "1.0.0",
+				tool.Dep("color-name", "1.1.3"),
+			),
+		)},
+		// yarn.lock workspace
+		{Path: "13", Result: tool.Dep("", "",
+			tool.Dep("js-workspace", "1.0.0"),
+			std.Children[0],
+			tool.Dep("js-lib", "1.0.0",
+				tool.Dep("color-name", "1.1.4"),
+			),
+		)},
 	})
 }
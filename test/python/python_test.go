@@ -3,12 +3,33 @@ package python
 import (
 	"testing"
 
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/python"
 	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
 )
 
 func Test_Python(t *testing.T) {
 
+	es := func() *model.DepGraph {
+		return tool.Dep("elasticsearch", "8.9.0",
+			tool.Dep("elastic-transport", "8.4.0",
+				tool.Dep("certifi", "2023.7.22"),
+				tool.Dep("urllib3", "1.26.16"),
+			),
+		)
+	}
+
+	pytest := func() *model.DepGraph {
+		return tool.DevDep("pytest", "7.4.0",
+			tool.DevDep("colorama", "0.4.6"),
+			tool.DevDep("iniconfig", "2.0.0"),
+			tool.DevDep("packaging", "23.1"),
+			tool.DevDep("pluggy", "1.2.0"),
+		)
+	}
+
+	certifi := tool.Dep("certifi", "2023.7.22")
+
 	tool.RunTaskCase(t, python.Sca{})([]tool.TaskCase{
 
 		// rquirements.txt
@@ -38,6 +59,96 @@ func Test_Python(t *testing.T) {
 			tool.Dep("certifi", "2023.7.22"),
 			tool.Dep("urllib3", "1.26.16"),
 		))},
+
+		// poetry.lock & pyproject.toml
+		{Path: "4", Result: tool.Dep("", "", tool.Dep("py-test", "0.1.0", es(), pytest()))},
+
+		// poetry.lock (category)
+		{Path: "5", Result: tool.Dep("", "", tool.Dep("", "", es(), pytest()))},
+
+		// pyproject.toml
+		{Path: "6", Result: tool.Dep("", "", tool.Dep("py-test", "0.1.0",
+			tool.Dep("elasticsearch", "^8.9.0"),
+			tool.DevDep("pytest", "^7.4.0"),
+		))},
+
+		// pyproject.toml (PEP 621)
+		{Path: "7", Result: tool.Dep("", "", tool.Dep("py-test", "0.1.0",
+			tool.Dep("click", ">=8.0"),
+			tool.Dep("elasticsearch", ">=8.9.0,<9"),
+			tool.Dep("requests", ">=2.31"),
+			tool.DevDep("pytest", ">=7.4.0"),
+			tool.DevDep("ruff", "==0.1.0"),
+		))},
+
+		// uv.lock
+		{Path: "8", Result: tool.Dep("", "", tool.Dep("py-test", "0.1.0", es(), pytest()))},
+
+		// pdm.lock & pyproject.toml
+		{Path: "9", Result: tool.Dep("", "", tool.Dep("py-test", "0.1.0",
+			tool.Dep("elasticsearch", "8.9.0",
+				tool.Dep("elastic-transport", "8.4.0",
+					certifi,
+					tool.Dep("urllib3", "1.26.16"),
+				),
+				tool.Dep("requests", "2.31.0", certifi),
+			),
+			pytest(),
+		))},
+
+		// Pipfile.lock (develop) & METADATA
+		{Path: "10", Result: tool.Dep("", "", tool.Dep("", "", es(), pytest()))},
+
+		// requirements.txt (-r & -c & --hash)
+		{Path: "11", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("click", ">=8.0"),
+			tool.Dep("elasticsearch", "8.9.0"),
+			tool.Dep("flask", ">=2.0,<3"),
+			tool.Dep("itsdangerous", ""),
+			tool.Dep("requests", "2.31.0"),
+			tool.Dep("six", ">=1.16"),
+			tool.Dep("urllib3", "1.26.16"),
+		))},
+
+		// site-packages
+		{Path: "12", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("PyYAML", "6.0.1"),
+			tool.Dep("requests", "2.31.0",
+				tool.Dep("certifi", "2023.7.22"),
+				tool.Dep("idna", "3.4"),
+				tool.Dep("urllib3", "1.26.16"),
+			),
+			tool.Dep("six", "1.16.0"),
+		))},
+
+		// uv.lock (extra循环引用)
+		{Path: "13", Result: tool.Dep("", "", tool.Dep("py-test", "0.1.0",
+			tool.Dep("a", "1.0.0", tool.Dep("b", "2.0.0")),
+		))},
+
+		// Pipfile.lock & 版本不一致的METADATA
+		{Path: "14", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("idna", "3.4"),
+			tool.Dep("requests", "2.31.0"),
+		))},
 	})
 
 }
+
+func Test_PythonRequirementHash(t *testing.T) {
+	root := python.ParseRequirementTxt(model.NewFile("11/requirements.txt", "11/requirements.txt"))
+	versionRange := map[string]bool{}
+	root.ForEachNode(func(p, n *model.DepGraph) bool {
+		versionRange[n.Name] = n.VersionRange
+		return true
+	})
+	// --hash不影响版本约束
+	for name, expect := range map[string]bool{
+		"six":      true,
+		"requests": false,
+	} {
+		if versionRange[name] != expect {
+			t.Errorf("%s version range:%v expect:%v", name, versionRange[name], expect)
+		}
+	}
+}
@@ -3,11 +3,17 @@ package ruby
 import (
 	"testing"
 
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/ruby"
 	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
 )
 
 func Test_Ruby(t *testing.T) {
+
+	activesupport := tool.Dep("activesupport", "7.1.0.alpha",
+		tool.Dep("concurrent-ruby", "1.2.2"),
+	)
+
 	tool.RunTaskCase(t, ruby.Sca{})([]tool.TaskCase{
 
 		// Gemfile.lock
@@ -22,5 +28,39 @@ func Test_Ruby(t *testing.T) {
 				tool.Dep("http_parser.rb", "0.8.0"),
 			),
 		)},
+
+		// Gemfile.lock (GIT & PATH) & Gemfile & gemspec
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("mygem", "0.1.0",
+			activesupport,
+			tool.Dep("nokogiri", "1.15.4",
+				tool.Dep("racc", "1.7.1"),
+			),
+			tool.DevDep("pry", "0.14.2",
+				tool.DevDep("coderay", "1.1.3"),
+				tool.DevDep("method_source", "1.0.0"),
+			),
+			tool.DevDep("rake", "13.0.6"),
+			tool.DevDep("rspec", "3.12.0",
+				tool.DevDep("diff-lcs", "1.5.0"),
+			),
+		))},
+	})
+}
+
+func Test_RubySource(t *testing.T) {
+	root := ruby.ParseGemfileLock(model.NewFile("2/Gemfile.lock", "2/Gemfile.lock"), nil)
+	source := map[string]string{}
+	root.ForEachNode(func(p, n *model.DepGraph) bool {
+		source[n.Name] = n.Source
+		return true
 	})
+	for name, expect := range map[string]string{
+		"nokogiri":      model.SourceRegistry,
+		"activesupport": model.SourceGit,
+		"mygem":         model.SourcePath,
+	} {
+		if source[name] != expect {
+			t.Errorf("%s source:%s expect:%s", name, source[name], expect)
+		}
+	}
 }
@@ -3,11 +3,22 @@ package rust
 import (
 	"testing"
 
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/model"
 	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/rust"
 	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
 )
 
 func Test_Rust(t *testing.T) {
+
+	serde := tool.Dep("serde", "1.0.188")
+	util := tool.Dep("util", "0.2.0", serde)
+	core := tool.Dep("demo-core", "1.0.0",
+		tool.Dep("anyhow", "1.0.75"),
+		tool.Dep("regex", "1.9.5"),
+		tool.Dep("libc", "0.2"),
+		tool.Dep("serde", "1.0"),
+	)
+
 	tool.RunTaskCase(t, rust.Sca{})([]tool.TaskCase{
 
 		// Cargo.lock
@@ -21,5 +32,78 @@ func Test_Rust(t *testing.T) {
 				),
 			),
 		)},
+
+		// cargo-auditable
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("app", "0.1.0",
+				tool.Dep("serde", "1.0.188",
+					tool.Dep("itoa", "1.0.9"),
+				),
+				tool.DevDep("cc", "1.0.83"),
+			),
+		))},
+
+		// Cargo.lock & Cargo.toml workspace
+		{Path: "3", Result: tool.Dep("", "",
+			tool.Dep("", "",
+				tool.Dep("app", "0.1.0",
+					tool.DevDep("cc", "1.0.83"),
+					tool.Dep("mylocal", ""),
+					tool.Dep("rand", "0.8.5"),
+					serde,
+					tool.DevDep("tempfile", "3.8.0",
+						tool.DevDep("fastrand", "2.0.0"),
+					),
+					util,
+				),
+				util,
+			),
+			tool.Dep("", "",
+				tool.Dep("skip", "0.0.1",
+					tool.Dep("log", "0.4"),
+				),
+			),
+		)},
+
+		// Cargo.toml workspace without Cargo.lock (成员间版本约束不同的开发依赖)
+		{Path: "4", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("demo-cli", "1.0.0",
+				tool.Dep("clap", ">=4.0, <5"),
+				core,
+				tool.DevDep("assert_cmd", "2"),
+				tool.DevDep("serde", "1.0.188"),
+			),
+			core,
+		))},
+
+		// Cargo.lock workspace (重命名依赖及成员间共享的开发依赖)
+		{Path: "5", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep("tool", "0.1.0",
+				tool.Dep("bytes", "1.5.0"),
+				tool.Dep("clap", "4.4.0"),
+			),
+			tool.Dep("web", "0.1.0",
+				tool.Dep("hyper", "0.14.27"),
+				tool.DevDep("serde_json", "1.0.107"),
+			),
+		))},
+	})
+}
+
+func Test_RustSource(t *testing.T) {
+	root := rust.ParseCargoLock(model.NewFile("3/Cargo.lock", "3/Cargo.lock"), nil, nil)
+	source := map[string]string{}
+	root.ForEachNode(func(p, n *model.DepGraph) bool {
+		source[n.Name] = n.Source
+		return true
 	})
+	for name, expect := range map[string]string{
+		"serde":   model.SourceRegistry,
+		"rand":    model.SourceGit,
+		"mylocal": model.SourcePath,
+	} {
+		if source[name] != expect {
+			t.Errorf("%s source:%s expect:%s", name, source[name], expect)
+		}
+	}
 }
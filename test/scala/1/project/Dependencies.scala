@@ -0,0 +1,10 @@
+import sbt._
+
+object Versions {
+  val akka = "2.6.20"
+  val slf4j = "2.0.9"
+}
+
+object Dependencies {
+  lazy val akkaActor = "com.typesafe.akka" %% "akka-actor" % Versions.akka
+}
@@ -0,0 +1,45 @@
+package scala
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/scala"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Scala(t *testing.T) {
+
+	java.RegisterMavenOrigin(tool.MavenOrigin("repo"))
+
+	tool.RunTaskCase(t, scala.Sca{})([]tool.TaskCase{
+
+		// build.sbt & project/*.scala
+		{Path: "1", Result: tool.Dep("", "", tool.Dep3("com.example", "my-app", "0.1.0",
+			tool.Dep3("org.typelevel", "cats-core_2.13", "2.9.0",
+				tool.Dep3("org.typelevel", "cats-kernel_2.13", "2.9.0"),
+			),
+			tool.Dep3("com.typesafe.akka", "akka-actor_2.13", "2.6.20",
+				tool.Dep3("com.typesafe", "config", "1.4.2"),
+			),
+			tool.Dep3("org.apache.httpcomponents", "httpclient", "4.5.14",
+				tool.Dep3("commons-codec", "commons-codec", "1.11"),
+			),
+			tool.DevDep3("org.scalatest", "scalatest_2.13", "3.2.15"),
+		))},
+
+		// build.sbt.lock
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("locked", "",
+			tool.Dep3("org.scala-lang", "scala3-library_3", "3.3.0"),
+			tool.Dep3("org.typelevel", "cats-core_3", "2.9.0"),
+			tool.Dep3("org.typelevel", "cats-kernel_3", "2.9.0"),
+			tool.DevDep3("org.scalameta", "munit_3", "0.7.29"),
+		))},
+
+		// build.sbt (多行exclude)
+		{Path: "3", Result: tool.Dep("", "", tool.Dep("multiline", "",
+			tool.Dep3("com.typesafe.akka", "akka-actor_2.13", "2.6.20"),
+			tool.Dep3("org.apache.httpcomponents", "httpclient", "4.5.14"),
+		))},
+	})
+}
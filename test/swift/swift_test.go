@@ -0,0 +1,40 @@
+package swift
+
+import (
+	"testing"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/swift"
+	"github.com/xmirrorsecurity/opensca-cli/v3/test/tool"
+)
+
+func Test_Swift(t *testing.T) {
+
+	alamofire := tool.Dep("Alamofire", "5.8.0")
+	utilities := tool.Dep("GoogleUtilities", "7.12.0")
+	core := tool.Dep("FirebaseCore", "10.18.0", utilities)
+
+	tool.RunTaskCase(t, swift.Sca{})([]tool.TaskCase{
+
+		// Package.resolved v3
+		{Path: "1", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep3("github.com/Alamofire", "Alamofire", "5.9.1"),
+			tool.Dep3("github.com/apple", "swift-log", "1.5.4"),
+			tool.Dep("localkit", ""),
+		))},
+
+		// Package.resolved v1
+		{Path: "2", Result: tool.Dep("", "", tool.Dep("", "",
+			tool.Dep3("github.com/SnapKit", "SnapKit", "5.6.0"),
+		))},
+
+		// Podfile.lock
+		{Path: "3", Result: tool.Dep("", "", tool.Dep("", "",
+			alamofire,
+			tool.Dep("Firebase", "10.18.0",
+				tool.Dep("FirebaseAnalytics", "10.18.0", core, utilities),
+				core,
+			),
+			tool.Dep("MyKit", "", alamofire),
+		))},
+	})
+}
@@ -0,0 +1,34 @@
+package tool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xmirrorsecurity/opensca-cli/v3/opensca/sca/java"
+)
+
+// MavenOrigin 使用本地目录作为maven数据源 目录结构同maven仓库
+func MavenOrigin(dir string) func(groupId, artifactId, version string) *java.Pom {
+	return func(groupId, artifactId, version string) *java.Pom {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(groupId, ".", "/")), artifactId, version, fmt.Sprintf("%s-%s.pom", artifactId, version)))
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		return java.ReadPom(f)
+	}
+}
+
+// MavenMetadataOrigin 使用本地目录中的maven-metadata.xml作为版本列表数据源
+func MavenMetadataOrigin(dir string) func(groupId, artifactId string) []string {
+	return func(groupId, artifactId string) []string {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(groupId, ".", "/")), artifactId, "maven-metadata.xml"))
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		return java.ReadMavenMetadata(f)
+	}
+}